@@ -0,0 +1,151 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// BudgetPeriod represents the span of time a budget covers
+type BudgetPeriod string
+
+// Periods a budget can cover
+const (
+	BudgetPeriodDay   BudgetPeriod = "day"   // Today
+	BudgetPeriodWeek  BudgetPeriod = "week"  // This ISO week, Monday to Sunday
+	BudgetPeriodMonth BudgetPeriod = "month" // This calendar month
+)
+
+// BudgetLevel represents how close spending is to a budget
+type BudgetLevel int
+
+// Budget levels, in increasing severity
+const (
+	BudgetLevelNormal   BudgetLevel = iota // Below the warning threshold
+	BudgetLevelWarning                     // At or above the warning threshold
+	BudgetLevelCritical                    // At or above the critical threshold
+)
+
+// Default budget thresholds in percent of the amount
+const (
+	defaultWarningPercent  = 80
+	defaultCriticalPercent = 100
+)
+
+// Budget represents a spending limit per period with thresholds that escalate the display
+type Budget struct {
+	Amount          float64      `json:"amount"`          // In the displayed currency
+	Period          BudgetPeriod `json:"period"`          // Defaults to month
+	WarningPercent  *float64     `json:"warningPercent"`  // Defaults to 80; 0 warns from the start
+	CriticalPercent *float64     `json:"criticalPercent"` // Defaults to 100
+}
+
+// NewBudget creates a budget of amount per period with the default thresholds
+func NewBudget(amount float64, period BudgetPeriod) Budget {
+	warning, critical := float64(defaultWarningPercent), float64(defaultCriticalPercent)
+	return Budget{Amount: amount, Period: period, WarningPercent: &warning, CriticalPercent: &critical}
+}
+
+// Thresholds returns the warning and critical thresholds, defaults for those not set
+func (b Budget) Thresholds() (warning, critical float64) {
+	warning, critical = defaultWarningPercent, defaultCriticalPercent
+	if b.WarningPercent != nil {
+		warning = *b.WarningPercent
+	}
+	if b.CriticalPercent != nil {
+		critical = *b.CriticalPercent
+	}
+	return warning, critical
+}
+
+// Validate fills in the defaults and checks that the budget makes sense
+func (b *Budget) Validate() error {
+	if b.Period == "" {
+		b.Period = BudgetPeriodMonth
+	}
+	warning, critical := b.Thresholds()
+	b.WarningPercent, b.CriticalPercent = &warning, &critical
+
+	switch b.Period {
+	case BudgetPeriodDay, BudgetPeriodWeek, BudgetPeriodMonth:
+	default:
+		return fmt.Errorf("unknown budget period %q (expected %s, %s or %s)", b.Period, BudgetPeriodDay, BudgetPeriodWeek, BudgetPeriodMonth)
+	}
+	if b.Amount <= 0 {
+		return fmt.Errorf("budget amount must be positive, got %v", b.Amount)
+	}
+	if warning < 0 || critical < 0 {
+		return fmt.Errorf("budget thresholds must not be negative, got %v%% and %v%%", warning, critical)
+	}
+	if warning > critical {
+		return fmt.Errorf("budget warning threshold %v%% is above the critical threshold %v%%", warning, critical)
+	}
+	return nil
+}
+
+// BudgetStatus represents spending against a budget
+type BudgetStatus struct {
+	Budget  Budget
+	Spent   float64
+	Percent float64 // Spent as a percentage of the budget amount, may exceed 100
+	Level   BudgetLevel
+}
+
+// Evaluate compares the amount spent in the current period with the budget
+func (b Budget) Evaluate(spent float64) *BudgetStatus {
+	status := &BudgetStatus{Budget: b, Spent: spent}
+	if b.Amount > 0 {
+		status.Percent = spent / b.Amount * 100
+	}
+	warning, critical := b.Thresholds()
+	switch {
+	case status.Percent >= critical:
+		status.Level = BudgetLevelCritical
+	case status.Percent >= warning:
+		status.Level = BudgetLevelWarning
+	}
+	return status
+}
+
+// Start returns the first day of the period containing now
+func (p BudgetPeriod) Start(now time.Time) time.Time {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch p {
+	case BudgetPeriodDay:
+		return today
+	case BudgetPeriodWeek:
+		// ISO weeks start on Monday
+		return today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	default:
+		return time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// PeriodCost returns the cost of the period containing now: today, this ISO week or this month
+func (r *CostResponse) PeriodCost(period BudgetPeriod, now time.Time) float64 {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	year, week := today.ISOWeek()
+
+	var cost float64
+	for _, day := range r.Daily {
+		date, err := time.Parse("2006-01-02", day.Date)
+		if err != nil || date.After(today) {
+			continue
+		}
+		switch period {
+		case BudgetPeriodDay:
+			if !date.Equal(today) {
+				continue
+			}
+		case BudgetPeriodWeek:
+			if dayYear, dayWeek := date.ISOWeek(); dayYear != year || dayWeek != week {
+				continue
+			}
+		default:
+			if date.Year() != today.Year() || date.Month() != today.Month() {
+				continue
+			}
+		}
+		cost += day.TotalCost
+	}
+	return cost
+}
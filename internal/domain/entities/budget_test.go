@@ -0,0 +1,168 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func percent(value float64) *float64 {
+	return &value
+}
+
+func TestBudgetValidate(t *testing.T) {
+	tests := []struct {
+		name              string
+		budget            Budget
+		wantPeriod        BudgetPeriod
+		warning, critical float64
+	}{
+		{"defaults", Budget{Amount: 100}, BudgetPeriodMonth, 80, 100},
+		{"week", Budget{Amount: 100, Period: BudgetPeriodWeek}, BudgetPeriodWeek, 80, 100},
+		{"custom thresholds", Budget{Amount: 5, Period: BudgetPeriodDay, WarningPercent: percent(50), CriticalPercent: percent(150)}, BudgetPeriodDay, 50, 150},
+		// Warning from the start, critical at the warning threshold
+		{"zero warning", Budget{Amount: 100, WarningPercent: percent(0)}, BudgetPeriodMonth, 0, 100},
+		{"equal thresholds", Budget{Amount: 100, WarningPercent: percent(90), CriticalPercent: percent(90)}, BudgetPeriodMonth, 90, 90},
+	}
+	for _, tt := range tests {
+		budget := tt.budget
+		if err := budget.Validate(); err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if budget.Period != tt.wantPeriod {
+			t.Errorf("%s: got period %q, want %q", tt.name, budget.Period, tt.wantPeriod)
+		}
+		// Validate fills the thresholds in
+		if budget.WarningPercent == nil || *budget.WarningPercent != tt.warning {
+			t.Errorf("%s: got warning %v, want %v", tt.name, budget.WarningPercent, tt.warning)
+		}
+		if budget.CriticalPercent == nil || *budget.CriticalPercent != tt.critical {
+			t.Errorf("%s: got critical %v, want %v", tt.name, budget.CriticalPercent, tt.critical)
+		}
+	}
+}
+
+func TestBudgetValidateErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		budget Budget
+		want   string
+	}{
+		{"unknown period", Budget{Amount: 100, Period: "year"}, `unknown budget period "year"`},
+		{"zero amount", Budget{}, "budget amount must be positive, got 0"},
+		{"negative amount", Budget{Amount: -10}, "budget amount must be positive, got -10"},
+		{"negative warning", Budget{Amount: 100, WarningPercent: percent(-1)}, "must not be negative, got -1% and 100%"},
+		{"negative critical", Budget{Amount: 100, WarningPercent: percent(0), CriticalPercent: percent(-5)}, "must not be negative"},
+		{"warning above critical", Budget{Amount: 100, WarningPercent: percent(120)}, "warning threshold 120% is above the critical threshold 100%"},
+	}
+	for _, tt := range tests {
+		budget := tt.budget
+		err := budget.Validate()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: got error %v, want one containing %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestNewBudget(t *testing.T) {
+	budget := NewBudget(100, BudgetPeriodWeek)
+	if budget.WarningPercent == nil || budget.CriticalPercent == nil {
+		t.Fatal("NewBudget left the thresholds unset")
+	}
+	if *budget.WarningPercent != 80 || *budget.CriticalPercent != 100 {
+		t.Errorf("got thresholds %v and %v, want 80 and 100", *budget.WarningPercent, *budget.CriticalPercent)
+	}
+	if err := budget.Validate(); err != nil {
+		t.Errorf("NewBudget is invalid: %v", err)
+	}
+}
+
+func TestBudgetEvaluate(t *testing.T) {
+	defaults := Budget{Amount: 100}
+	custom := Budget{Amount: 200, WarningPercent: percent(50), CriticalPercent: percent(75)}
+
+	tests := []struct {
+		name        string
+		budget      Budget
+		spent       float64
+		wantPercent float64
+		wantLevel   BudgetLevel
+	}{
+		{"nothing spent", defaults, 0, 0, BudgetLevelNormal},
+		{"below warning", defaults, 79.99, 79.99, BudgetLevelNormal},
+		// Each threshold is reached at exactly its percentage
+		{"at warning", defaults, 80, 80, BudgetLevelWarning},
+		{"below critical", defaults, 99.99, 99.99, BudgetLevelWarning},
+		{"at critical", defaults, 100, 100, BudgetLevelCritical},
+		{"over budget", defaults, 250, 250, BudgetLevelCritical},
+		{"custom below warning", custom, 99, 49.5, BudgetLevelNormal},
+		{"custom at warning", custom, 100, 50, BudgetLevelWarning},
+		{"custom at critical", custom, 150, 75, BudgetLevelCritical},
+		{"zero warning", Budget{Amount: 100, WarningPercent: percent(0)}, 0, 0, BudgetLevelWarning},
+	}
+	for _, tt := range tests {
+		status := tt.budget.Evaluate(tt.spent)
+		assertCost(t, tt.name+" percent", status.Percent, tt.wantPercent)
+		if status.Level != tt.wantLevel {
+			t.Errorf("%s: got level %v, want %v", tt.name, status.Level, tt.wantLevel)
+		}
+		if status.Spent != tt.spent {
+			t.Errorf("%s: got spent %v, want %v", tt.name, status.Spent, tt.spent)
+		}
+	}
+}
+
+func TestPeriodCost(t *testing.T) {
+	// A Thursday in the ISO week from Monday 2026-10-12
+	now := time.Date(2026, 10, 15, 18, 0, 0, 0, time.UTC)
+	costData := dailyCosts(t,
+		"2026-09-30", 1.0, // last month
+		"2026-10-01", 2.0,
+		"2026-10-11", 4.0, // Sunday of the week before
+		"2026-10-12", 8.0,
+		"2026-10-15", 16.0,
+		"2026-10-16", 32.0, // tomorrow
+	)
+
+	tests := []struct {
+		period BudgetPeriod
+		want   float64
+	}{
+		{BudgetPeriodDay, 16},
+		{BudgetPeriodWeek, 24},
+		{BudgetPeriodMonth, 30},
+	}
+	for _, tt := range tests {
+		assertCost(t, string(tt.period), costData.PeriodCost(tt.period, now), tt.want)
+	}
+}
+
+func TestPeriodCostWeekAcrossYears(t *testing.T) {
+	// ISO week 1 of 2027 starts on Monday 2026-12-28
+	now := time.Date(2027, 1, 2, 12, 0, 0, 0, time.UTC)
+	costData := dailyCosts(t, "2026-12-27", 1.0, "2026-12-28", 2.0, "2027-01-01", 4.0, "2027-01-02", 8.0)
+
+	assertCost(t, "week", costData.PeriodCost(BudgetPeriodWeek, now), 14)
+	assertCost(t, "month", costData.PeriodCost(BudgetPeriodMonth, now), 12)
+}
+
+func TestBudgetPeriodStart(t *testing.T) {
+	tests := []struct {
+		period BudgetPeriod
+		now    time.Time
+		want   string
+	}{
+		{BudgetPeriodDay, time.Date(2026, 10, 15, 18, 0, 0, 0, time.UTC), "2026-10-15"},
+		{BudgetPeriodWeek, time.Date(2026, 10, 15, 18, 0, 0, 0, time.UTC), "2026-10-12"},
+		{BudgetPeriodWeek, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), "2026-10-12"},
+		{BudgetPeriodWeek, time.Date(2026, 10, 18, 23, 0, 0, 0, time.UTC), "2026-10-12"},
+		{BudgetPeriodWeek, time.Date(2027, 1, 2, 12, 0, 0, 0, time.UTC), "2026-12-28"},
+		{BudgetPeriodMonth, time.Date(2026, 10, 15, 18, 0, 0, 0, time.UTC), "2026-10-01"},
+	}
+	for _, tt := range tests {
+		if got := tt.period.Start(tt.now).Format(dateLayout); got != tt.want {
+			t.Errorf("%s of %s: got %s, want %s", tt.period, tt.now.Format(dateLayout), got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package entities
+
+import "sort"
+
+// PricingLookup returns the pricing of a model
+type PricingLookup func(modelName string) (*ModelPricing, error)
+
+// CacheEfficiency represents how well prompt caching worked for a day, a model or all usage
+type CacheEfficiency struct {
+	Key                 string // Date or model name, empty for all usage
+	InputTokens         int
+	CacheCreationTokens int
+	CacheReadTokens     int
+	Saved               float64 // Cost of the cache reads as uncached input, minus their actual cost
+	WritePremium        float64 // Cost of the cache writes above the same tokens as uncached input
+	Unpriced            int     // Model breakdowns left out of the dollar figures for lack of (cache) pricing
+}
+
+// HitRatio returns the share of prompt tokens that were read from the cache
+func (e *CacheEfficiency) HitRatio() float64 {
+	prompt := e.InputTokens + e.CacheCreationTokens + e.CacheReadTokens
+	if prompt == 0 {
+		return 0
+	}
+	return float64(e.CacheReadTokens) / float64(prompt)
+}
+
+// NetSaved returns the savings of cache reads minus the premium paid for cache writes
+func (e *CacheEfficiency) NetSaved() float64 {
+	return e.Saved - e.WritePremium
+}
+
+// addSavings adds the dollar figures of a model breakdown
+func (e *CacheEfficiency) addSavings(breakdown *ModelBreakdown, lookup PricingLookup) {
+	if breakdown.CacheReadTokens == 0 && breakdown.CacheCreationTokens == 0 {
+		return
+	}
+	pricing, err := lookup(breakdown.ModelName)
+	if err != nil || (pricing.CacheReadCostPerToken == 0 && pricing.CacheCreationCostPerToken == 0) {
+		// Without cache prices every read would count as saved at the full input price
+		e.Unpriced++
+		return
+	}
+	e.Saved += float64(breakdown.CacheReadTokens) * (pricing.InputCostPerToken - pricing.CacheReadCostPerToken)
+	e.WritePremium += float64(breakdown.CacheCreationTokens) * (pricing.CacheCreationCostPerToken - pricing.InputCostPerToken)
+}
+
+// addTokens adds the token counts of a model breakdown
+func (e *CacheEfficiency) addTokens(breakdown *ModelBreakdown) {
+	e.InputTokens += breakdown.InputTokens
+	e.CacheCreationTokens += breakdown.CacheCreationTokens
+	e.CacheReadTokens += breakdown.CacheReadTokens
+}
+
+// CacheEfficiencyByDay returns the cache efficiency of every day, in the order of the days
+func (r *CostResponse) CacheEfficiencyByDay(lookup PricingLookup) []CacheEfficiency {
+	days := make([]CacheEfficiency, 0, len(r.Daily))
+	for _, day := range r.Daily {
+		efficiency := CacheEfficiency{
+			Key:                 day.Date,
+			InputTokens:         day.InputTokens,
+			CacheCreationTokens: day.CacheCreationTokens,
+			CacheReadTokens:     day.CacheReadTokens,
+		}
+		for i := range day.ModelBreakdowns {
+			efficiency.addSavings(&day.ModelBreakdowns[i], lookup)
+		}
+		if len(day.ModelBreakdowns) == 0 && (day.CacheReadTokens > 0 || day.CacheCreationTokens > 0) {
+			// Without breakdowns there is no model to price the tokens at
+			efficiency.Unpriced++
+		}
+		days = append(days, efficiency)
+	}
+	return days
+}
+
+// CacheEfficiencyByModel returns the cache efficiency of every model group, largest net savings
+// first. Models are priced by their raw IDs before they are grouped.
+func (r *CostResponse) CacheEfficiencyByModel(lookup PricingLookup, grouping ModelGrouping) []CacheEfficiency {
+	index := make(map[string]int)
+	models := []CacheEfficiency{}
+	for _, day := range r.Daily {
+		for i := range day.ModelBreakdowns {
+			breakdown := &day.ModelBreakdowns[i]
+			label := grouping.Label(breakdown.ModelName)
+			j, ok := index[label]
+			if !ok {
+				j = len(models)
+				index[label] = j
+				models = append(models, CacheEfficiency{Key: label})
+			}
+			models[j].addTokens(breakdown)
+			models[j].addSavings(breakdown, lookup)
+		}
+	}
+	sort.SliceStable(models, func(i, j int) bool {
+		return models[i].NetSaved() > models[j].NetSaved()
+	})
+	return models
+}
+
+// CacheEfficiencyTotal returns the cache efficiency of all usage
+func (r *CostResponse) CacheEfficiencyTotal(lookup PricingLookup) CacheEfficiency {
+	total := CacheEfficiency{}
+	for _, day := range r.CacheEfficiencyByDay(lookup) {
+		total.InputTokens += day.InputTokens
+		total.CacheCreationTokens += day.CacheCreationTokens
+		total.CacheReadTokens += day.CacheReadTokens
+		total.Saved += day.Saved
+		total.WritePremium += day.WritePremium
+		total.Unpriced += day.Unpriced
+	}
+	return total
+}
@@ -0,0 +1,155 @@
+package entities
+
+import (
+	"fmt"
+	"testing"
+)
+
+// cachePricing looks up round per-token prices with cache prices for sonnet and opus models,
+// and prices without them for gpt-4o
+func cachePricing(modelName string) (*ModelPricing, error) {
+	switch LookupModel(modelName).Family {
+	case ModelFamilySonnet:
+		return &ModelPricing{InputCostPerToken: 1, OutputCostPerToken: 5, CacheCreationCostPerToken: 1.25, CacheReadCostPerToken: 0.1}, nil
+	case ModelFamilyOpus:
+		return &ModelPricing{InputCostPerToken: 5, OutputCostPerToken: 25, CacheCreationCostPerToken: 6.25, CacheReadCostPerToken: 0.5}, nil
+	}
+	if modelName == "gpt-4o" {
+		return &ModelPricing{InputCostPerToken: 1, OutputCostPerToken: 2}, nil
+	}
+	return nil, fmt.Errorf("no pricing for %s", modelName)
+}
+
+// cacheDay builds a day whose token counts are the sums of its model breakdowns
+func cacheDay(date string, breakdowns ...ModelBreakdown) DailyUsage {
+	day := DailyUsage{Date: date, ModelBreakdowns: breakdowns}
+	for _, breakdown := range breakdowns {
+		day.InputTokens += breakdown.InputTokens
+		day.CacheCreationTokens += breakdown.CacheCreationTokens
+		day.CacheReadTokens += breakdown.CacheReadTokens
+	}
+	return day
+}
+
+// cacheUsage returns the usage of four days: one with cache reads, one with cache writes but
+// no reads, one with a model without cache prices and an unknown model, and one without breakdowns
+func cacheUsage() *CostResponse {
+	return &CostResponse{Daily: []DailyUsage{
+		cacheDay("2026-10-01", ModelBreakdown{ModelName: "claude-sonnet-4-20250514", InputTokens: 300, CacheCreationTokens: 200, CacheReadTokens: 1000}),
+		cacheDay("2026-10-02", ModelBreakdown{ModelName: "claude-opus-4-20250514", InputTokens: 100, CacheCreationTokens: 400}),
+		cacheDay("2026-10-03",
+			ModelBreakdown{ModelName: "gpt-4o", InputTokens: 100, CacheCreationTokens: 100, CacheReadTokens: 500},
+			ModelBreakdown{ModelName: "mystery-model", CacheReadTokens: 10},
+			// Without cache tokens there is nothing to price
+			ModelBreakdown{ModelName: "mystery-model", InputTokens: 20},
+		),
+		{Date: "2026-10-04", CacheReadTokens: 50},
+	}}
+}
+
+func TestCacheEfficiencyByDay(t *testing.T) {
+	tests := []struct {
+		key                             string
+		saved, writePremium, netSaved   float64
+		unpriced                        int
+		input, cacheCreation, cacheRead int
+	}{
+		// Reads save the input price less the read price, writes cost the write price over the input price
+		{"2026-10-01", 900, 50, 850, 0, 300, 200, 1000},
+		{"2026-10-02", 0, 500, -500, 0, 100, 400, 0},
+		// A model without cache prices and an unknown one are counted, not priced
+		{"2026-10-03", 0, 0, 0, 2, 120, 100, 510},
+		{"2026-10-04", 0, 0, 0, 1, 0, 0, 50},
+	}
+
+	days := cacheUsage().CacheEfficiencyByDay(cachePricing)
+	if len(days) != len(tests) {
+		t.Fatalf("got %d days, want %d", len(days), len(tests))
+	}
+	for i, tt := range tests {
+		day := days[i]
+		if day.Key != tt.key {
+			t.Errorf("day %d: got key %q, want %q", i, day.Key, tt.key)
+		}
+		assertCost(t, tt.key+" saved", day.Saved, tt.saved)
+		assertCost(t, tt.key+" write premium", day.WritePremium, tt.writePremium)
+		assertCost(t, tt.key+" net saved", day.NetSaved(), tt.netSaved)
+		if day.Unpriced != tt.unpriced {
+			t.Errorf("%s: got %d unpriced, want %d", tt.key, day.Unpriced, tt.unpriced)
+		}
+		if day.InputTokens != tt.input || day.CacheCreationTokens != tt.cacheCreation || day.CacheReadTokens != tt.cacheRead {
+			t.Errorf("%s: got tokens %d/%d/%d, want %d/%d/%d", tt.key,
+				day.InputTokens, day.CacheCreationTokens, day.CacheReadTokens, tt.input, tt.cacheCreation, tt.cacheRead)
+		}
+	}
+}
+
+func TestCacheEfficiencyTotal(t *testing.T) {
+	total := cacheUsage().CacheEfficiencyTotal(cachePricing)
+
+	if total.Key != "" {
+		t.Errorf("got key %q, want none", total.Key)
+	}
+	assertCost(t, "saved", total.Saved, 900)
+	assertCost(t, "write premium", total.WritePremium, 550)
+	assertCost(t, "net saved", total.NetSaved(), 350)
+	if total.Unpriced != 3 {
+		t.Errorf("got %d unpriced, want 3", total.Unpriced)
+	}
+	// 1560 of 2780 prompt tokens were read from the cache
+	assertCost(t, "hit ratio", total.HitRatio(), 1560.0/2780)
+}
+
+func TestCacheEfficiencyByModel(t *testing.T) {
+	tests := []struct {
+		grouping ModelGrouping
+		keys     []string
+		netSaved []float64
+	}{
+		// Largest net savings first, ties in the order the models appear
+		{ModelGroupingModel, []string{"Sonnet 4", "gpt-4o", "mystery-model", "Opus 4"}, []float64{850, 0, 0, -500}},
+		{ModelGroupingFamily, []string{"Sonnet", "gpt-4o", "mystery-model", "Opus"}, []float64{850, 0, 0, -500}},
+	}
+	for _, tt := range tests {
+		models := cacheUsage().CacheEfficiencyByModel(cachePricing, tt.grouping)
+		if len(models) != len(tt.keys) {
+			t.Errorf("%s: got %d models, want %d", tt.grouping, len(models), len(tt.keys))
+			continue
+		}
+		for i, model := range models {
+			if model.Key != tt.keys[i] {
+				t.Errorf("%s model %d: got %q, want %q", tt.grouping, i, model.Key, tt.keys[i])
+			}
+			assertCost(t, fmt.Sprintf("%s %s net saved", tt.grouping, model.Key), model.NetSaved(), tt.netSaved[i])
+		}
+	}
+
+	// Models are priced by their raw IDs, then grouped
+	usage := &CostResponse{Daily: []DailyUsage{
+		cacheDay("2026-10-01",
+			ModelBreakdown{ModelName: "claude-sonnet-4-20250514", CacheReadTokens: 100},
+			ModelBreakdown{ModelName: "claude-3-5-sonnet-20241022", CacheReadTokens: 100},
+		),
+	}}
+	models := usage.CacheEfficiencyByModel(cachePricing, ModelGroupingFamily)
+	if len(models) != 1 || models[0].Key != "Sonnet" || models[0].CacheReadTokens != 200 {
+		t.Fatalf("got %+v, want one Sonnet group with 200 cache reads", models)
+	}
+	assertCost(t, "Sonnet saved", models[0].Saved, 180)
+}
+
+func TestCacheEfficiencyHitRatio(t *testing.T) {
+	tests := []struct {
+		name       string
+		efficiency CacheEfficiency
+		want       float64
+	}{
+		{"no prompt tokens", CacheEfficiency{}, 0},
+		{"no cache reads", CacheEfficiency{InputTokens: 100, CacheCreationTokens: 300}, 0},
+		{"only cache reads", CacheEfficiency{CacheReadTokens: 500}, 1},
+		{"mixed", CacheEfficiency{InputTokens: 100, CacheCreationTokens: 100, CacheReadTokens: 200}, 0.5},
+	}
+	for _, tt := range tests {
+		assertCost(t, tt.name, tt.efficiency.HitRatio(), tt.want)
+	}
+}
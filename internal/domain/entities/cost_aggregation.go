@@ -0,0 +1,111 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// PeriodUsage represents the usage of the days within one week or month
+type PeriodUsage struct {
+	Period              string    // ISO week such as 2025-W02, or month such as 2025-01
+	Start               time.Time // Midnight of the period's first day in the rollup's timezone
+	End                 time.Time // Midnight of the day after the period's last day
+	Days                int       // Days with usage
+	InputTokens         int
+	OutputTokens        int
+	CacheCreationTokens int
+	CacheReadTokens     int
+	TotalTokens         int
+	TotalCost           float64
+	ModelsUsed          []string
+	ModelBreakdowns     []ModelBreakdown
+}
+
+// WeeklyRollup groups the days into ISO weeks (Monday to Sunday) in the given timezone,
+// oldest first; a nil location means local time
+func (r *CostResponse) WeeklyRollup(location *time.Location) ([]PeriodUsage, error) {
+	return r.rollup(location, func(day time.Time) (string, time.Time, time.Time) {
+		year, week := day.ISOWeek()
+		// Go numbers Sunday 0, ISO weeks start on Monday
+		start := day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+		return fmt.Sprintf("%04d-W%02d", year, week), start, start.AddDate(0, 0, 7)
+	})
+}
+
+// MonthlyRollup groups the days into calendar months in the given timezone, oldest first;
+// a nil location means local time
+func (r *CostResponse) MonthlyRollup(location *time.Location) ([]PeriodUsage, error) {
+	return r.rollup(location, func(day time.Time) (string, time.Time, time.Time) {
+		start := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+		return start.Format("2006-01"), start, start.AddDate(0, 1, 0)
+	})
+}
+
+// rollup groups the days into the periods returned by bucket
+func (r *CostResponse) rollup(location *time.Location, bucket func(day time.Time) (string, time.Time, time.Time)) ([]PeriodUsage, error) {
+	if location == nil {
+		location = time.Local
+	}
+
+	periods := []PeriodUsage{}
+	index := make(map[string]int)
+	for _, day := range r.Daily {
+		date, err := time.ParseInLocation("2006-01-02", day.Date, location)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q: %w", day.Date, err)
+		}
+
+		key, start, end := bucket(date)
+		i, ok := index[key]
+		if !ok {
+			i = len(periods)
+			index[key] = i
+			periods = append(periods, PeriodUsage{Period: key, Start: start, End: end})
+		}
+
+		period := &periods[i]
+		period.Days++
+		period.InputTokens += day.InputTokens
+		period.OutputTokens += day.OutputTokens
+		period.CacheCreationTokens += day.CacheCreationTokens
+		period.CacheReadTokens += day.CacheReadTokens
+		period.TotalTokens += day.TotalTokens
+		period.TotalCost += day.TotalCost
+		period.ModelsUsed = mergeModelNames(period.ModelsUsed, day.ModelsUsed)
+		period.ModelBreakdowns = mergeModelBreakdowns(period.ModelBreakdowns, day.ModelBreakdowns)
+	}
+
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].Start.Before(periods[j].Start)
+	})
+	return periods, nil
+}
+
+// ModelTotals sums the model breakdowns of every day per model, most expensive first
+func (r *CostResponse) ModelTotals() []ModelBreakdown {
+	totals := []ModelBreakdown{}
+	for _, day := range r.Daily {
+		totals = mergeModelBreakdowns(totals, day.ModelBreakdowns)
+	}
+	return totals
+}
+
+// TopDays returns the n most expensive days, most expensive first; ties keep date order
+func (r *CostResponse) TopDays(n int) []DailyUsage {
+	days := make([]DailyUsage, len(r.Daily))
+	copy(days, r.Daily)
+	sort.SliceStable(days, func(i, j int) bool {
+		if days[i].TotalCost != days[j].TotalCost {
+			return days[i].TotalCost > days[j].TotalCost
+		}
+		return days[i].Date < days[j].Date
+	})
+	if n < 0 {
+		n = 0
+	}
+	if n < len(days) {
+		days = days[:n]
+	}
+	return days
+}
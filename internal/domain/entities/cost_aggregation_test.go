@@ -0,0 +1,231 @@
+package entities
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// loadFixture reads a ccusage daily report from testdata
+func loadFixture(t *testing.T, name string) *CostResponse {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var response CostResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		t.Fatal(err)
+	}
+	return &response
+}
+
+func assertCost(t *testing.T, name string, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("%s: got cost %v, want %v", name, got, want)
+	}
+}
+
+func TestWeeklyRollup(t *testing.T) {
+	response := loadFixture(t, "daily.json")
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	weeks, err := response.WeeklyRollup(tokyo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		period string
+		start  time.Time
+		days   int
+		tokens int
+		cost   float64
+	}{
+		// 2024-12-30 belongs to the first ISO week of 2025
+		{"2025-W01", time.Date(2024, 12, 30, 0, 0, 0, 0, tokyo), 3, 300 + 810 + 1100, 17.5},
+		{"2025-W02", time.Date(2025, 1, 6, 0, 0, 0, 0, tokyo), 1, 150, 1.25},
+		{"2025-W05", time.Date(2025, 1, 27, 0, 0, 0, 0, tokyo), 2, 190 + 40, 7},
+	}
+	if len(weeks) != len(want) {
+		t.Fatalf("got %d weeks, want %d: %+v", len(weeks), len(want), weeks)
+	}
+	for i, w := range want {
+		week := weeks[i]
+		if week.Period != w.period {
+			t.Errorf("week %d: got period %s, want %s", i, week.Period, w.period)
+		}
+		if !week.Start.Equal(w.start) || !week.End.Equal(w.start.AddDate(0, 0, 7)) {
+			t.Errorf("%s: got %s to %s, want a week from %s", w.period, week.Start, week.End, w.start)
+		}
+		if week.Start.Location() != tokyo {
+			t.Errorf("%s: got location %s, want %s", w.period, week.Start.Location(), tokyo)
+		}
+		if week.Days != w.days {
+			t.Errorf("%s: got %d days, want %d", w.period, week.Days, w.days)
+		}
+		if week.TotalTokens != w.tokens {
+			t.Errorf("%s: got %d tokens, want %d", w.period, week.TotalTokens, w.tokens)
+		}
+		assertCost(t, w.period, week.TotalCost, w.cost)
+	}
+}
+
+func TestMonthlyRollup(t *testing.T) {
+	response := loadFixture(t, "daily.json")
+
+	months, err := response.MonthlyRollup(time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		period string
+		days   int
+		cost   float64
+		models []string
+	}{
+		{"2024-12", 1, 10, []string{"claude-opus-4-20250514"}},
+		{"2025-01", 4, 14.75, []string{"claude-opus-4-20250514", "claude-sonnet-4-20250514"}},
+		{"2025-02", 1, 1, []string{"claude-3-5-haiku-20241022", "claude-sonnet-4-20250514"}},
+	}
+	if len(months) != len(want) {
+		t.Fatalf("got %d months, want %d: %+v", len(months), len(want), months)
+	}
+	for i, w := range want {
+		month := months[i]
+		if month.Period != w.period {
+			t.Errorf("month %d: got period %s, want %s", i, month.Period, w.period)
+		}
+		if month.Days != w.days {
+			t.Errorf("%s: got %d days, want %d", w.period, month.Days, w.days)
+		}
+		if len(month.ModelsUsed) != len(w.models) {
+			t.Errorf("%s: got models %v, want %v", w.period, month.ModelsUsed, w.models)
+		} else {
+			for j := range w.models {
+				if month.ModelsUsed[j] != w.models[j] {
+					t.Errorf("%s: got models %v, want %v", w.period, month.ModelsUsed, w.models)
+					break
+				}
+			}
+		}
+		assertCost(t, w.period, month.TotalCost, w.cost)
+	}
+
+	// January has 31 days
+	if end := months[1].End; !end.Equal(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("2025-01: got end %s, want 2025-02-01", end)
+	}
+	// The breakdowns of a month add up to the month
+	var breakdownCost float64
+	for _, breakdown := range months[1].ModelBreakdowns {
+		breakdownCost += breakdown.Cost
+	}
+	assertCost(t, "2025-01 breakdowns", breakdownCost, months[1].TotalCost)
+}
+
+func TestRollupDoesNotModifyDays(t *testing.T) {
+	response := loadFixture(t, "daily.json")
+	before := response.Daily[1].ModelBreakdowns[0]
+
+	if _, err := response.MonthlyRollup(time.UTC); err != nil {
+		t.Fatal(err)
+	}
+	response.ModelTotals()
+
+	if after := response.Daily[1].ModelBreakdowns[0]; after != before {
+		t.Errorf("breakdown changed from %+v to %+v", before, after)
+	}
+}
+
+func TestRollupInvalidDate(t *testing.T) {
+	response := &CostResponse{Daily: []DailyUsage{{Date: "2025/01/01"}}}
+	if _, err := response.WeeklyRollup(time.UTC); err == nil {
+		t.Error("expected an error for a malformed date")
+	}
+}
+
+func TestModelTotals(t *testing.T) {
+	response := loadFixture(t, "daily.json")
+
+	totals := response.ModelTotals()
+
+	want := []struct {
+		model        string
+		inputTokens  int
+		outputTokens int
+		cost         float64
+	}{
+		{"claude-opus-4-20250514", 240, 360, 17},
+		{"claude-sonnet-4-20250514", 880, 1100, 8.5},
+		{"claude-3-5-haiku-20241022", 5, 5, 0.25},
+	}
+	if len(totals) != len(want) {
+		t.Fatalf("got %d models, want %d: %+v", len(totals), len(want), totals)
+	}
+	for i, w := range want {
+		total := totals[i]
+		if total.ModelName != w.model {
+			t.Errorf("model %d: got %s, want %s", i, total.ModelName, w.model)
+			continue
+		}
+		if total.InputTokens != w.inputTokens || total.OutputTokens != w.outputTokens {
+			t.Errorf("%s: got %d/%d tokens, want %d/%d",
+				w.model, total.InputTokens, total.OutputTokens, w.inputTokens, w.outputTokens)
+		}
+		assertCost(t, w.model, total.Cost, w.cost)
+	}
+}
+
+func TestTopDays(t *testing.T) {
+	response := loadFixture(t, "daily.json")
+
+	tests := []struct {
+		n    int
+		want []string
+	}{
+		{3, []string{"2024-12-30", "2025-01-31", "2025-01-05"}},
+		{0, []string{}},
+		{-1, []string{}},
+		{10, []string{"2024-12-30", "2025-01-31", "2025-01-05", "2025-01-01", "2025-01-06", "2025-02-01"}},
+	}
+	for _, tt := range tests {
+		days := response.TopDays(tt.n)
+		if len(days) != len(tt.want) {
+			t.Errorf("TopDays(%d): got %d days, want %d", tt.n, len(days), len(tt.want))
+			continue
+		}
+		for i, date := range tt.want {
+			if days[i].Date != date {
+				t.Errorf("TopDays(%d)[%d]: got %s, want %s", tt.n, i, days[i].Date, date)
+			}
+		}
+	}
+
+	// The daily rows keep their order
+	if response.Daily[0].Date != "2024-12-30" || response.Daily[5].Date != "2025-02-01" {
+		t.Error("TopDays reordered the daily rows")
+	}
+}
+
+func TestTopDaysTiesKeepDateOrder(t *testing.T) {
+	response := &CostResponse{Daily: []DailyUsage{
+		{Date: "2025-01-03", TotalCost: 1},
+		{Date: "2025-01-01", TotalCost: 1},
+		{Date: "2025-01-02", TotalCost: 2},
+	}}
+
+	days := response.TopDays(3)
+
+	want := []string{"2025-01-02", "2025-01-01", "2025-01-03"}
+	for i, date := range want {
+		if days[i].Date != date {
+			t.Errorf("TopDays[%d]: got %s, want %s", i, days[i].Date, date)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package entities
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// AnomalyOptions represents the tuning of spend anomaly detection
+type AnomalyOptions struct {
+	Window     int     // Trailing days the baseline is taken from
+	MinHistory int     // Days of history needed before a day can be judged
+	Threshold  float64 // Robust z-score above which a day is an anomaly
+	MinExcess  float64 // USD above the baseline a day must reach to count, so cents never alarm
+}
+
+// DefaultAnomalyOptions returns the options used unless configured otherwise: a two-week
+// baseline and the customary 3.5 cut-off for robust z-scores
+func DefaultAnomalyOptions() AnomalyOptions {
+	return AnomalyOptions{Window: 14, MinHistory: 7, Threshold: 3.5, MinExcess: 1}
+}
+
+// CostAnomaly represents a day that cost abnormally more than the days before it
+type CostAnomaly struct {
+	Date          string  `json:"date"`
+	Cost          float64 `json:"cost"`
+	Baseline      float64 `json:"baseline"` // Median daily cost of the trailing window
+	Score         float64 `json:"score"`    // Robust z-score: distance from the median in scaled MADs
+	Model         string  `json:"model"`    // Model whose cost rose the most above its own baseline
+	ModelCost     float64 `json:"modelCost"`
+	ModelBaseline float64 `json:"modelBaseline"`
+}
+
+// Excess returns how much more the day cost than the baseline
+func (a *CostAnomaly) Excess() float64 {
+	return a.Cost - a.Baseline
+}
+
+// madScale turns a median absolute deviation into an estimate of the standard deviation
+const madScale = 1.4826
+
+// DetectAnomalies flags days whose cost is far above the median of the trailing window, measured
+// in median absolute deviations, and names the model behind each spike. Days without usage
+// count as zero cost. Anomalies are returned oldest first.
+func (r *CostResponse) DetectAnomalies(options AnomalyOptions) []CostAnomaly {
+	days := denseDays(r.Daily)
+	anomalies := []CostAnomaly{}
+
+	for i := range days {
+		start := max(0, i-options.Window)
+		history := days[start:i]
+		if len(history) < options.MinHistory || len(history) == 0 {
+			continue
+		}
+
+		costs := make([]float64, len(history))
+		for j, day := range history {
+			costs[j] = day.TotalCost
+		}
+		baseline := median(costs)
+		excess := days[i].TotalCost - baseline
+		if excess < options.MinExcess {
+			continue
+		}
+
+		deviations := make([]float64, len(costs))
+		for j, cost := range costs {
+			deviations[j] = math.Abs(cost - baseline)
+		}
+		// A flat history has no deviation; a cent keeps the score finite
+		scale := math.Max(madScale*median(deviations), 0.01)
+		score := excess / scale
+		if score < options.Threshold {
+			continue
+		}
+
+		anomaly := CostAnomaly{
+			Date:     days[i].Date,
+			Cost:     days[i].TotalCost,
+			Baseline: baseline,
+			Score:    score,
+		}
+		anomaly.Model, anomaly.ModelCost, anomaly.ModelBaseline = spikeModel(days[i], history)
+		anomalies = append(anomalies, anomaly)
+	}
+
+	return anomalies
+}
+
+// spikeModel returns the model of the day whose cost rose the most above its median in the history
+func spikeModel(day DailyUsage, history []DailyUsage) (string, float64, float64) {
+	var model string
+	var modelCost, modelBaseline float64
+	bestExcess := math.Inf(-1)
+	for _, breakdown := range day.ModelBreakdowns {
+		costs := make([]float64, len(history))
+		for j, past := range history {
+			for _, pastBreakdown := range past.ModelBreakdowns {
+				if pastBreakdown.ModelName == breakdown.ModelName {
+					costs[j] += pastBreakdown.Cost
+				}
+			}
+		}
+		baseline := median(costs)
+		if excess := breakdown.Cost - baseline; excess > bestExcess {
+			bestExcess = excess
+			model, modelCost, modelBaseline = breakdown.ModelName, breakdown.Cost, baseline
+		}
+	}
+	return model, modelCost, modelBaseline
+}
+
+// denseDays returns the days sorted by date with the days without usage in between filled in
+func denseDays(daily []DailyUsage) []DailyUsage {
+	byDate := make(map[string]DailyUsage, len(daily))
+	var dates []time.Time
+	for _, day := range daily {
+		date, err := time.Parse("2006-01-02", day.Date)
+		if err != nil {
+			continue
+		}
+		if _, seen := byDate[day.Date]; !seen {
+			dates = append(dates, date)
+		}
+		byDate[day.Date] = day
+	}
+	if len(dates) == 0 {
+		return nil
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	var days []DailyUsage
+	for date := dates[0]; !date.After(dates[len(dates)-1]); date = date.AddDate(0, 0, 1) {
+		key := date.Format("2006-01-02")
+		day, ok := byDate[key]
+		if !ok {
+			day = DailyUsage{Date: key}
+		}
+		days = append(days, day)
+	}
+	return days
+}
+
+// median returns the middle value of values, or 0 when there are none
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
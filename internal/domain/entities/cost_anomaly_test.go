@@ -0,0 +1,141 @@
+package entities
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// usageDay builds a day whose total cost is the sum of its model costs
+func usageDay(date string, breakdowns ...ModelBreakdown) DailyUsage {
+	day := DailyUsage{Date: date, ModelBreakdowns: breakdowns}
+	for _, breakdown := range breakdowns {
+		day.TotalCost += breakdown.Cost
+	}
+	return day
+}
+
+// usageWeek builds the seven days starting on start, each a copy of models
+func usageWeek(t *testing.T, start string, models ...ModelBreakdown) []DailyUsage {
+	t.Helper()
+	date, err := time.Parse("2006-01-02", start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	days := make([]DailyUsage, 7)
+	for i := range days {
+		days[i] = usageDay(date.AddDate(0, 0, i).Format("2006-01-02"), models...)
+	}
+	return days
+}
+
+func TestDetectAnomalies(t *testing.T) {
+	sonnet := func(cost float64) ModelBreakdown {
+		return ModelBreakdown{ModelName: "claude-sonnet-4-20250514", Cost: cost}
+	}
+	opus := func(cost float64) ModelBreakdown {
+		return ModelBreakdown{ModelName: "claude-opus-4-20250514", Cost: cost}
+	}
+
+	rising := []DailyUsage{}
+	for i, cost := range []float64{1, 2, 3, 4, 5, 6, 7} {
+		rising = append(rising, usageDay(time.Date(2025, 3, i+1, 0, 0, 0, 0, time.UTC).Format("2006-01-02"), sonnet(cost)))
+	}
+
+	tests := []struct {
+		name  string
+		daily []DailyUsage
+		want  []CostAnomaly
+	}{
+		{
+			// A flat history has no deviation, the 0.01 floor turns $1.50 into a score of 150
+			name:  "flat history",
+			daily: append(usageWeek(t, "2025-03-01", sonnet(2)), usageDay("2025-03-08", sonnet(3.5))),
+			want: []CostAnomaly{{Date: "2025-03-08", Cost: 3.5, Baseline: 2, Score: 150,
+				Model: "claude-sonnet-4-20250514", ModelCost: 3.5, ModelBaseline: 2}},
+		},
+		{
+			name:  "below the minimum excess",
+			daily: append(usageWeek(t, "2025-03-01", sonnet(2)), usageDay("2025-03-08", sonnet(2.5))),
+			want:  []CostAnomaly{},
+		},
+		{
+			name:  "too little history",
+			daily: append(usageWeek(t, "2025-03-01", sonnet(2))[1:], usageDay("2025-03-08", sonnet(50))),
+			want:  []CostAnomaly{},
+		},
+		{
+			// The seven days without usage in between count as history at no cost
+			name:  "gap-filled days",
+			daily: []DailyUsage{usageDay("2025-03-09", sonnet(5)), usageDay("2025-03-01", sonnet(0.5))},
+			want: []CostAnomaly{{Date: "2025-03-09", Cost: 5, Baseline: 0, Score: 500,
+				Model: "claude-sonnet-4-20250514", ModelCost: 5, ModelBaseline: 0}},
+		},
+		{
+			// 12 scores (12-4)/(1.4826*2) against the median 4 and MAD 2 of 1 to 7, below the
+			// threshold; 20 then scores (20-4.5)/(1.4826*2) with 12 in the history
+			name:  "noisy history",
+			daily: append(append([]DailyUsage{}, rising...), usageDay("2025-03-08", sonnet(12)), usageDay("2025-03-09", sonnet(20))),
+			want: []CostAnomaly{{Date: "2025-03-09", Cost: 20, Baseline: 4.5, Score: 15.5 / (madScale * 2),
+				Model: "claude-sonnet-4-20250514", ModelCost: 20, ModelBaseline: 4.5}},
+		},
+		{
+			// Opus costs more on the day, but Sonnet is the model that rose
+			name:  "spike attribution",
+			daily: append(usageWeek(t, "2025-03-01", sonnet(2), opus(5)), usageDay("2025-03-08", sonnet(10), opus(4))),
+			want: []CostAnomaly{{Date: "2025-03-08", Cost: 14, Baseline: 7, Score: 700,
+				Model: "claude-sonnet-4-20250514", ModelCost: 10, ModelBaseline: 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		response := &CostResponse{Daily: tt.daily}
+		anomalies := response.DetectAnomalies(DefaultAnomalyOptions())
+		if len(anomalies) != len(tt.want) {
+			t.Errorf("%s: got %d anomalies, want %d: %+v", tt.name, len(anomalies), len(tt.want), anomalies)
+			continue
+		}
+		for i, want := range tt.want {
+			got := anomalies[i]
+			if got.Date != want.Date || got.Model != want.Model {
+				t.Errorf("%s: got %s by %s, want %s by %s", tt.name, got.Date, got.Model, want.Date, want.Model)
+			}
+			assertCost(t, tt.name+" cost", got.Cost, want.Cost)
+			assertCost(t, tt.name+" baseline", got.Baseline, want.Baseline)
+			assertCost(t, tt.name+" model cost", got.ModelCost, want.ModelCost)
+			assertCost(t, tt.name+" model baseline", got.ModelBaseline, want.ModelBaseline)
+			if math.Abs(got.Score-want.Score) > 1e-6 {
+				t.Errorf("%s: got score %v, want %v", tt.name, got.Score, want.Score)
+			}
+		}
+	}
+}
+
+func TestSpikeModel(t *testing.T) {
+	history := []DailyUsage{
+		usageDay("2025-03-01", ModelBreakdown{ModelName: "sonnet", Cost: 2}, ModelBreakdown{ModelName: "opus", Cost: 6}),
+		usageDay("2025-03-02", ModelBreakdown{ModelName: "sonnet", Cost: 2}),
+		usageDay("2025-03-03", ModelBreakdown{ModelName: "sonnet", Cost: 2}, ModelBreakdown{ModelName: "opus", Cost: 6}),
+	}
+
+	tests := []struct {
+		name     string
+		day      DailyUsage
+		model    string
+		cost     float64
+		baseline float64
+	}{
+		{"rise over own median", usageDay("2025-03-04", ModelBreakdown{ModelName: "sonnet", Cost: 5}, ModelBreakdown{ModelName: "opus", Cost: 8}), "sonnet", 5, 2},
+		{"model new to the history", usageDay("2025-03-04", ModelBreakdown{ModelName: "sonnet", Cost: 4}, ModelBreakdown{ModelName: "haiku", Cost: 3}), "haiku", 3, 0},
+		{"every model cheaper", usageDay("2025-03-04", ModelBreakdown{ModelName: "sonnet", Cost: 1}, ModelBreakdown{ModelName: "opus", Cost: 1}), "sonnet", 1, 2},
+		{"no breakdowns", DailyUsage{Date: "2025-03-04", TotalCost: 10}, "", 0, 0},
+	}
+	for _, tt := range tests {
+		model, cost, baseline := spikeModel(tt.day, history)
+		if model != tt.model {
+			t.Errorf("%s: got model %q, want %q", tt.name, model, tt.model)
+		}
+		assertCost(t, tt.name+" cost", cost, tt.cost)
+		assertCost(t, tt.name+" baseline", baseline, tt.baseline)
+	}
+}
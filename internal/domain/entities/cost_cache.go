@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// CachedCostResponse represents a previously fetched CostResponse stored on disk
+type CachedCostResponse struct {
+	Response  *CostResponse `json:"response"`
+	FetchedAt time.Time     `json:"fetchedAt"`
+}
+
+// IsFresh returns true if the cached data is younger than ttl
+func (c *CachedCostResponse) IsFresh(ttl time.Duration, now time.Time) bool {
+	return now.Sub(c.FetchedAt) < ttl
+}
+
+// StaleDataError is returned alongside cached data when a fetch fails
+type StaleDataError struct {
+	FetchedAt time.Time
+	Err       error
+}
+
+// Error returns a one-line description of the failure
+func (e *StaleDataError) Error() string {
+	return fmt.Sprintf("showing data from %s: %v", e.FetchedAt.Format("2006-01-02 15:04"), e.Err)
+}
+
+// Unwrap returns the fetch error
+func (e *StaleDataError) Unwrap() error {
+	return e.Err
+}
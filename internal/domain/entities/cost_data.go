@@ -1,7 +1,5 @@
 package entities
 
-import "fmt"
-
 // CostResponse represents the response from ccusage API
 type CostResponse struct {
 	Daily  []DailyUsage `json:"daily"`
@@ -43,5 +41,35 @@ type Totals struct {
 
 // FormatCost formats the total cost as a string for display
 func (t *Totals) FormatCost() string {
-	return fmt.Sprintf("$%.2f", t.TotalCost)
+	return FormatCost(t.TotalCost)
+}
+
+// FormatCost formats a USD amount as a string for display
+func FormatCost(amount float64) string {
+	return builtinCurrencies[CurrencyUSD].Format(amount)
+}
+
+// FilterByDateRange returns a copy containing only the days within the range, with totals recomputed
+func (r *CostResponse) FilterByDateRange(dateRange DateRange) *CostResponse {
+	filtered := &CostResponse{Daily: []DailyUsage{}}
+	for _, day := range r.Daily {
+		if dateRange.Contains(day.Date) {
+			filtered.Daily = append(filtered.Daily, day)
+		}
+	}
+	filtered.RecalculateTotals()
+	return filtered
+}
+
+// RecalculateTotals recomputes Totals from the daily rows
+func (r *CostResponse) RecalculateTotals() {
+	r.Totals = Totals{}
+	for _, day := range r.Daily {
+		r.Totals.InputTokens += day.InputTokens
+		r.Totals.OutputTokens += day.OutputTokens
+		r.Totals.CacheCreationTokens += day.CacheCreationTokens
+		r.Totals.CacheReadTokens += day.CacheReadTokens
+		r.Totals.TotalTokens += day.TotalTokens
+		r.Totals.TotalCost += day.TotalCost
+	}
 }
@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// CostFetchErrorKind identifies why fetching cost data failed
+type CostFetchErrorKind int
+
+const (
+	CostFetchCommandNotFound CostFetchErrorKind = iota // npx is not installed or not on PATH
+	CostFetchCommandFailed                             // the command exited with a non-zero status
+	CostFetchTimeout                                   // the command did not finish in time
+	CostFetchDecodeFailed                              // the command output is not valid ccusage JSON
+	CostFetchInvalidInput                              // an --input file is not a valid ccusage JSON report
+)
+
+// CostFetchError represents a failure to fetch cost data from ccusage
+type CostFetchError struct {
+	Kind     CostFetchErrorKind
+	Command  string // The command that was run, or the input file name
+	ExitCode int
+	Stderr   string
+	Timeout  time.Duration
+	Err      error
+}
+
+// Error returns a one-line description of the failure
+func (e *CostFetchError) Error() string {
+	switch e.Kind {
+	case CostFetchCommandNotFound:
+		return fmt.Sprintf("%s: command not found", e.Command)
+	case CostFetchCommandFailed:
+		return fmt.Sprintf("%s exited with status %d", e.Command, e.ExitCode)
+	case CostFetchTimeout:
+		return fmt.Sprintf("%s timed out after %s", e.Command, e.Timeout)
+	case CostFetchDecodeFailed:
+		return fmt.Sprintf("could not decode %s output: %v", e.Command, e.Err)
+	case CostFetchInvalidInput:
+		return fmt.Sprintf("invalid cost data in %s: %v", e.Command, e.Err)
+	default:
+		return fmt.Sprintf("%s failed: %v", e.Command, e.Err)
+	}
+}
+
+// Unwrap returns the underlying error
+func (e *CostFetchError) Unwrap() error {
+	return e.Err
+}
+
+// Hint returns a suggestion for resolving the failure
+func (e *CostFetchError) Hint() string {
+	switch e.Kind {
+	case CostFetchCommandNotFound:
+		return "Install Node.js, or use --source native to read Claude Code logs directly"
+	case CostFetchCommandFailed:
+		return "Check the command output below, or try --source native"
+	case CostFetchTimeout:
+		return "npx may still be downloading ccusage; retry or raise --timeout"
+	case CostFetchDecodeFailed:
+		return "ccusage may have changed its JSON format; try --source native"
+	case CostFetchInvalidInput:
+		return "The input must be the JSON output of `ccusage daily -j` (or monthly, session or blocks)"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,120 @@
+package entities
+
+import (
+	"math"
+	"time"
+)
+
+// DefaultForecastWindow is the number of trailing days the trailing-average model averages over
+const DefaultForecastWindow = 7
+
+// forecastZ is the z-score of the two-sided 95% confidence band
+const forecastZ = 1.96
+
+// CostForecast represents the projected cost of a month at its end
+type CostForecast struct {
+	Month           string  // YYYY-MM
+	ToDate          float64 // Cost so far this month, today included
+	DaysElapsed     int     // Days of the month so far, today included
+	DaysInMonth     int
+	TrailingAverage float64 // Month-end total at the average daily cost of the trailing window
+	Regression      float64 // Month-end total following the linear trend of this month's daily costs
+	Expected        float64 // Mean of both models
+	Low             float64 // Lower end of the ~95% confidence band, never below ToDate
+	High            float64 // Upper end of the ~95% confidence band
+}
+
+// ForecastMonth projects the cost of the month containing now from its daily costs so far,
+// averaging the last window days for the trailing-average model
+func (r *CostResponse) ForecastMonth(now time.Time, window int) *CostForecast {
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	forecast := &CostForecast{
+		Month:       monthStart.Format("2006-01"),
+		DaysElapsed: now.Day(),
+		DaysInMonth: monthStart.AddDate(0, 1, -1).Day(),
+	}
+
+	// Daily costs of this month by day of month, days without usage cost nothing
+	costs := make([]float64, forecast.DaysElapsed)
+	for _, day := range r.Daily {
+		if len(day.Date) != len("2006-01-02") || day.Date[:7] != forecast.Month {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", day.Date)
+		if err != nil || date.Day() > forecast.DaysElapsed {
+			continue
+		}
+		costs[date.Day()-1] += day.TotalCost
+	}
+	for _, cost := range costs {
+		forecast.ToDate += cost
+	}
+	remaining := forecast.DaysInMonth - forecast.DaysElapsed
+
+	// Trailing average: the last window days repeat until the month ends
+	if window <= 0 || window > len(costs) {
+		window = len(costs)
+	}
+	average := mean(costs[len(costs)-window:])
+	forecast.TrailingAverage = forecast.ToDate + average*float64(remaining)
+
+	// Linear regression: the trend of daily costs continues, but no day costs less than nothing
+	intercept, slope, residual := linearFit(costs)
+	forecast.Regression = forecast.ToDate
+	for day := forecast.DaysElapsed + 1; day <= forecast.DaysInMonth; day++ {
+		forecast.Regression += math.Max(0, intercept+slope*float64(day))
+	}
+
+	forecast.Expected = (forecast.TrailingAverage + forecast.Regression) / 2
+
+	// Daily noise adds up over the remaining days; the band also covers both models
+	spread := forecastZ * residual * math.Sqrt(float64(remaining))
+	forecast.Low = math.Max(forecast.ToDate, math.Min(forecast.Expected-spread, math.Min(forecast.TrailingAverage, forecast.Regression)))
+	forecast.High = math.Max(forecast.Expected+spread, math.Max(forecast.TrailingAverage, forecast.Regression))
+
+	return forecast
+}
+
+// mean returns the average of values, or 0 when there are none
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+// linearFit fits costs[i] = intercept + slope*(i+1) by least squares and returns the standard
+// deviation of the residuals. With fewer than three points the trend is flat and the deviation
+// is that of the costs themselves.
+func linearFit(costs []float64) (intercept, slope, residual float64) {
+	n := float64(len(costs))
+	average := mean(costs)
+	if len(costs) < 3 {
+		if len(costs) == 2 {
+			residual = math.Abs(costs[1]-costs[0]) / math.Sqrt2
+		}
+		return average, 0, residual
+	}
+
+	meanX := (n + 1) / 2
+	var covariance, variance float64
+	for i, cost := range costs {
+		dx := float64(i+1) - meanX
+		covariance += dx * (cost - average)
+		variance += dx * dx
+	}
+	slope = covariance / variance
+	intercept = average - slope*meanX
+
+	var squares float64
+	for i, cost := range costs {
+		diff := cost - (intercept + slope*float64(i+1))
+		squares += diff * diff
+	}
+	residual = math.Sqrt(squares / (n - 2))
+	return intercept, slope, residual
+}
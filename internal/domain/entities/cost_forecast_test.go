@@ -0,0 +1,124 @@
+package entities
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// dailyCosts builds a daily report with one day per date and cost pair
+func dailyCosts(t *testing.T, pairs ...any) *CostResponse {
+	t.Helper()
+	if len(pairs)%2 != 0 {
+		t.Fatal("dailyCosts needs date and cost pairs")
+	}
+	response := &CostResponse{}
+	for i := 0; i < len(pairs); i += 2 {
+		response.Daily = append(response.Daily, DailyUsage{Date: pairs[i].(string), TotalCost: pairs[i+1].(float64)})
+	}
+	return response
+}
+
+func TestForecastMonth(t *testing.T) {
+	tests := []struct {
+		name            string
+		response        *CostResponse
+		now             time.Time
+		window          int
+		month           string
+		daysElapsed     int
+		daysInMonth     int
+		toDate          float64
+		trailingAverage float64
+		regression      float64
+		low             float64
+		high            float64
+	}{
+		{
+			name: "flat",
+			response: dailyCosts(t, "2025-04-01", 2.0, "2025-04-02", 2.0, "2025-04-03", 2.0, "2025-04-04", 2.0,
+				"2025-04-05", 2.0, "2025-04-06", 2.0, "2025-04-07", 2.0, "2025-04-08", 2.0, "2025-04-09", 2.0, "2025-04-10", 2.0),
+			now:    time.Date(2025, 4, 10, 15, 0, 0, 0, time.UTC),
+			window: DefaultForecastWindow,
+			month:  "2025-04", daysElapsed: 10, daysInMonth: 30,
+			toDate: 20, trailingAverage: 60, regression: 60, low: 60, high: 60,
+		},
+		{
+			// The trailing average only sees days 3 to 5, the trend continues up to day 28
+			name:     "rising",
+			response: dailyCosts(t, "2025-02-01", 1.0, "2025-02-02", 2.0, "2025-02-03", 3.0, "2025-02-04", 4.0, "2025-02-05", 5.0),
+			now:      time.Date(2025, 2, 5, 9, 0, 0, 0, time.UTC),
+			window:   3,
+			month:    "2025-02", daysElapsed: 5, daysInMonth: 28,
+			toDate: 15, trailingAverage: 15 + 4*23, regression: 406, low: 15 + 4*23, high: 406,
+		},
+		{
+			// The falling trend reaches zero on day 4 and never goes below it; other months, later
+			// days and malformed dates do not count
+			name: "falling",
+			response: dailyCosts(t, "2025-05-31", 100.0, "2025-06-01", 6.0, "2025-06-02", 4.0, "2025-06-03", 2.0,
+				"2025-06-04", 100.0, "2025/06/02", 100.0),
+			now:    time.Date(2025, 6, 3, 23, 0, 0, 0, time.UTC),
+			window: DefaultForecastWindow,
+			month:  "2025-06", daysElapsed: 3, daysInMonth: 30,
+			toDate: 12, trailingAverage: 12 + 4*27, regression: 12, low: 12, high: 12 + 4*27,
+		},
+		{
+			// Days without usage cost nothing; the noise of 0, 6, 0 around the flat trend of 2 widens
+			// the band by sqrt(24) a day over the 27 remaining days
+			name:     "gaps",
+			response: dailyCosts(t, "2025-04-02", 6.0),
+			now:      time.Date(2025, 4, 3, 12, 0, 0, 0, time.UTC),
+			window:   2,
+			month:    "2025-04", daysElapsed: 3, daysInMonth: 30,
+			toDate: 6, trailingAverage: 6 + 3*27, regression: 6 + 2*27,
+			low:  73.5 - forecastZ*math.Sqrt(24*27),
+			high: 73.5 + forecastZ*math.Sqrt(24*27),
+		},
+		{
+			name:     "last day",
+			response: dailyCosts(t, "2024-02-28", 3.0, "2024-02-29", 5.0),
+			now:      time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC),
+			window:   DefaultForecastWindow,
+			month:    "2024-02", daysElapsed: 29, daysInMonth: 29,
+			toDate: 8, trailingAverage: 8, regression: 8, low: 8, high: 8,
+		},
+	}
+
+	for _, tt := range tests {
+		forecast := tt.response.ForecastMonth(tt.now, tt.window)
+		if forecast.Month != tt.month || forecast.DaysElapsed != tt.daysElapsed || forecast.DaysInMonth != tt.daysInMonth {
+			t.Errorf("%s: got %s day %d of %d, want %s day %d of %d", tt.name,
+				forecast.Month, forecast.DaysElapsed, forecast.DaysInMonth, tt.month, tt.daysElapsed, tt.daysInMonth)
+		}
+		assertCost(t, tt.name+" to date", forecast.ToDate, tt.toDate)
+		assertCost(t, tt.name+" trailing average", forecast.TrailingAverage, tt.trailingAverage)
+		assertCost(t, tt.name+" expected", forecast.Expected, (forecast.TrailingAverage+forecast.Regression)/2)
+		assertCost(t, tt.name+" regression", forecast.Regression, tt.regression)
+		assertCost(t, tt.name+" low", forecast.Low, tt.low)
+		assertCost(t, tt.name+" high", forecast.High, tt.high)
+	}
+}
+
+func TestLinearFit(t *testing.T) {
+	tests := []struct {
+		costs     []float64
+		intercept float64
+		slope     float64
+		residual  float64
+	}{
+		{nil, 0, 0, 0},
+		{[]float64{5}, 5, 0, 0},
+		// Too few points for a trend, the deviation is that of the costs
+		{[]float64{1, 3}, 2, 0, math.Sqrt2},
+		{[]float64{1, 2, 3, 4}, 0, 1, 0},
+		{[]float64{1, 3, 2}, 1, 0.5, math.Sqrt(1.5)},
+	}
+	for _, tt := range tests {
+		intercept, slope, residual := linearFit(tt.costs)
+		if math.Abs(intercept-tt.intercept) > 1e-9 || math.Abs(slope-tt.slope) > 1e-9 || math.Abs(residual-tt.residual) > 1e-9 {
+			t.Errorf("linearFit(%v): got %v + %vx ± %v, want %v + %vx ± %v",
+				tt.costs, intercept, slope, residual, tt.intercept, tt.slope, tt.residual)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package entities
+
+import "sort"
+
+// SourceCostResponse represents the cost data of a single source, such as one machine's export
+type SourceCostResponse struct {
+	Source   string
+	Response *CostResponse
+}
+
+// MergeCostResponses combines cost data from several sources into one: days are combined by date,
+// model breakdowns by model name, and totals are recomputed
+func MergeCostResponses(responses ...*CostResponse) *CostResponse {
+	merged := &CostResponse{Daily: []DailyUsage{}}
+	index := make(map[string]int)
+
+	for _, response := range responses {
+		for _, day := range response.Daily {
+			i, ok := index[day.Date]
+			if !ok {
+				index[day.Date] = len(merged.Daily)
+				merged.Daily = append(merged.Daily, DailyUsage{Date: day.Date})
+				i = len(merged.Daily) - 1
+			}
+			target := &merged.Daily[i]
+			target.InputTokens += day.InputTokens
+			target.OutputTokens += day.OutputTokens
+			target.CacheCreationTokens += day.CacheCreationTokens
+			target.CacheReadTokens += day.CacheReadTokens
+			target.TotalTokens += day.TotalTokens
+			target.TotalCost += day.TotalCost
+			target.ModelBreakdowns = mergeModelBreakdowns(target.ModelBreakdowns, day.ModelBreakdowns)
+			target.ModelsUsed = mergeModelNames(target.ModelsUsed, day.ModelsUsed)
+		}
+	}
+
+	sort.Slice(merged.Daily, func(i, j int) bool {
+		return merged.Daily[i].Date < merged.Daily[j].Date
+	})
+	merged.RecalculateTotals()
+
+	return merged
+}
+
+// MergeMonthlyResponses combines monthly reports from several sources by month
+func MergeMonthlyResponses(responses ...*MonthlyResponse) *MonthlyResponse {
+	merged := &MonthlyResponse{Monthly: []MonthlyUsage{}}
+	index := make(map[string]int)
+
+	for _, response := range responses {
+		for _, month := range response.Monthly {
+			i, ok := index[month.Month]
+			if !ok {
+				index[month.Month] = len(merged.Monthly)
+				merged.Monthly = append(merged.Monthly, MonthlyUsage{Month: month.Month})
+				i = len(merged.Monthly) - 1
+			}
+			target := &merged.Monthly[i]
+			target.InputTokens += month.InputTokens
+			target.OutputTokens += month.OutputTokens
+			target.CacheCreationTokens += month.CacheCreationTokens
+			target.CacheReadTokens += month.CacheReadTokens
+			target.TotalTokens += month.TotalTokens
+			target.TotalCost += month.TotalCost
+			target.ModelBreakdowns = mergeModelBreakdowns(target.ModelBreakdowns, month.ModelBreakdowns)
+			target.ModelsUsed = mergeModelNames(target.ModelsUsed, month.ModelsUsed)
+		}
+		addTotals(&merged.Totals, &response.Totals)
+	}
+
+	sort.Slice(merged.Monthly, func(i, j int) bool {
+		return merged.Monthly[i].Month < merged.Monthly[j].Month
+	})
+
+	return merged
+}
+
+// MergeSessionResponses combines session reports from several sources; sessions never overlap
+func MergeSessionResponses(responses ...*SessionResponse) *SessionResponse {
+	merged := &SessionResponse{Sessions: []SessionUsage{}}
+	for _, response := range responses {
+		merged.Sessions = append(merged.Sessions, response.Sessions...)
+		addTotals(&merged.Totals, &response.Totals)
+	}
+	return merged
+}
+
+// MergeBlocksResponses combines block reports from several sources, ordered by start time
+func MergeBlocksResponses(responses ...*BlocksResponse) *BlocksResponse {
+	merged := &BlocksResponse{Blocks: []Block{}}
+	for _, response := range responses {
+		merged.Blocks = append(merged.Blocks, response.Blocks...)
+	}
+	sort.SliceStable(merged.Blocks, func(i, j int) bool {
+		return merged.Blocks[i].StartTime.Before(merged.Blocks[j].StartTime)
+	})
+	return merged
+}
+
+// mergeModelBreakdowns adds the breakdowns of src into dst by model name, most expensive first
+func mergeModelBreakdowns(dst, src []ModelBreakdown) []ModelBreakdown {
+	for _, breakdown := range src {
+		merged := false
+		for i := range dst {
+			if dst[i].ModelName == breakdown.ModelName {
+				dst[i].InputTokens += breakdown.InputTokens
+				dst[i].OutputTokens += breakdown.OutputTokens
+				dst[i].CacheCreationTokens += breakdown.CacheCreationTokens
+				dst[i].CacheReadTokens += breakdown.CacheReadTokens
+				dst[i].Cost += breakdown.Cost
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			dst = append(dst, breakdown)
+		}
+	}
+	sort.SliceStable(dst, func(i, j int) bool {
+		return dst[i].Cost > dst[j].Cost
+	})
+	return dst
+}
+
+// mergeModelNames returns the sorted union of two model name lists
+func mergeModelNames(dst, src []string) []string {
+	for _, name := range src {
+		found := false
+		for _, existing := range dst {
+			if existing == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst = append(dst, name)
+		}
+	}
+	sort.Strings(dst)
+	return dst
+}
+
+// addTotals adds src to dst
+func addTotals(dst, src *Totals) {
+	dst.InputTokens += src.InputTokens
+	dst.OutputTokens += src.OutputTokens
+	dst.CacheCreationTokens += src.CacheCreationTokens
+	dst.CacheReadTokens += src.CacheReadTokens
+	dst.TotalTokens += src.TotalTokens
+	dst.TotalCost += src.TotalCost
+}
@@ -0,0 +1,130 @@
+package entities
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// machineReports returns the daily reports of two machines that both have usage on 2026-10-02.
+// Their totals are stale to show that merging recomputes them.
+func machineReports() (*CostResponse, *CostResponse) {
+	laptop := &CostResponse{
+		Daily: []DailyUsage{
+			{
+				Date: "2026-10-02", InputTokens: 100, OutputTokens: 10, CacheReadTokens: 1000, TotalTokens: 1110, TotalCost: 3,
+				ModelsUsed: []string{"claude-sonnet-4-20250514"},
+				ModelBreakdowns: []ModelBreakdown{
+					{ModelName: "claude-sonnet-4-20250514", InputTokens: 100, OutputTokens: 10, CacheReadTokens: 1000, Cost: 3},
+				},
+			},
+			{
+				Date: "2026-10-01", InputTokens: 50, TotalTokens: 50, TotalCost: 1,
+				ModelsUsed:      []string{"claude-haiku-4-5-20251001"},
+				ModelBreakdowns: []ModelBreakdown{{ModelName: "claude-haiku-4-5-20251001", InputTokens: 50, Cost: 1}},
+			},
+		},
+		Totals: Totals{TotalCost: 100},
+	}
+	desktop := &CostResponse{
+		Daily: []DailyUsage{
+			{
+				Date: "2026-10-02", InputTokens: 20, OutputTokens: 5, CacheCreationTokens: 300, TotalTokens: 325, TotalCost: 7,
+				ModelsUsed: []string{"claude-opus-4-20250514", "claude-sonnet-4-20250514"},
+				ModelBreakdowns: []ModelBreakdown{
+					{ModelName: "claude-sonnet-4-20250514", InputTokens: 10, CacheCreationTokens: 300, Cost: 2},
+					{ModelName: "claude-opus-4-20250514", InputTokens: 10, OutputTokens: 5, Cost: 5},
+				},
+			},
+			{Date: "2026-10-03", InputTokens: 5, TotalTokens: 5, TotalCost: 0.5},
+		},
+		Totals: Totals{TotalCost: 100},
+	}
+	return laptop, desktop
+}
+
+func TestMergeCostResponses(t *testing.T) {
+	laptop, desktop := machineReports()
+	merged := MergeCostResponses(laptop, desktop)
+
+	var dates []string
+	for _, day := range merged.Daily {
+		dates = append(dates, day.Date)
+	}
+	if got := strings.Join(dates, ","); got != "2026-10-01,2026-10-02,2026-10-03" {
+		t.Fatalf("got days %s, want 2026-10-01 to 2026-10-03 in order", got)
+	}
+
+	// The shared date sums both machines, by model too
+	shared := merged.Daily[1]
+	want := DailyUsage{
+		Date: "2026-10-02", InputTokens: 120, OutputTokens: 15, CacheCreationTokens: 300, CacheReadTokens: 1000, TotalTokens: 1435, TotalCost: 10,
+		ModelsUsed: []string{"claude-opus-4-20250514", "claude-sonnet-4-20250514"},
+		ModelBreakdowns: []ModelBreakdown{
+			{ModelName: "claude-sonnet-4-20250514", InputTokens: 110, OutputTokens: 10, CacheCreationTokens: 300, CacheReadTokens: 1000, Cost: 5},
+			{ModelName: "claude-opus-4-20250514", InputTokens: 10, OutputTokens: 5, Cost: 5},
+		},
+	}
+	if !reflect.DeepEqual(shared, want) {
+		t.Errorf("got shared day %+v, want %+v", shared, want)
+	}
+
+	// Totals are recomputed from the days, not added up from the stale inputs
+	wantTotals := Totals{InputTokens: 175, OutputTokens: 15, CacheCreationTokens: 300, CacheReadTokens: 1000, TotalTokens: 1490, TotalCost: 11.5}
+	if merged.Totals != wantTotals {
+		t.Errorf("got totals %+v, want %+v", merged.Totals, wantTotals)
+	}
+}
+
+func TestMergeCostResponsesBreakdownOrder(t *testing.T) {
+	first := &CostResponse{Daily: []DailyUsage{{Date: "2026-10-01", ModelBreakdowns: []ModelBreakdown{
+		{ModelName: "haiku", Cost: 3},
+		{ModelName: "sonnet", Cost: 2},
+	}}}}
+	second := &CostResponse{Daily: []DailyUsage{{Date: "2026-10-01", ModelBreakdowns: []ModelBreakdown{
+		{ModelName: "sonnet", Cost: 2},
+		{ModelName: "opus", Cost: 3},
+	}}}}
+
+	// Most expensive first, ties in the order the models first appear
+	var names []string
+	for _, breakdown := range MergeCostResponses(first, second).Daily[0].ModelBreakdowns {
+		names = append(names, breakdown.ModelName)
+	}
+	if got := strings.Join(names, ","); got != "sonnet,haiku,opus" {
+		t.Errorf("got models %s, want sonnet,haiku,opus", got)
+	}
+}
+
+func TestMergeCostResponsesDoesNotModifyInputs(t *testing.T) {
+	laptop, desktop := machineReports()
+	MergeCostResponses(laptop, desktop)
+	// Merging the result again must not change it either
+	merged := MergeCostResponses(laptop, desktop)
+	MergeCostResponses(merged, laptop)
+
+	wantLaptop, wantDesktop := machineReports()
+	if !reflect.DeepEqual(laptop, wantLaptop) {
+		t.Errorf("first input changed: got %+v, want %+v", laptop, wantLaptop)
+	}
+	if !reflect.DeepEqual(desktop, wantDesktop) {
+		t.Errorf("second input changed: got %+v, want %+v", desktop, wantDesktop)
+	}
+	if again := MergeCostResponses(wantLaptop, wantDesktop); !reflect.DeepEqual(merged, again) {
+		t.Errorf("merged result changed: got %+v, want %+v", merged, again)
+	}
+}
+
+func TestMergeCostResponsesEmpty(t *testing.T) {
+	merged := MergeCostResponses()
+	if merged.Daily == nil || len(merged.Daily) != 0 || merged.Totals != (Totals{}) {
+		t.Errorf("got %+v, want no days and zero totals", merged)
+	}
+
+	laptop, _ := machineReports()
+	single := MergeCostResponses(laptop, &CostResponse{})
+	if len(single.Daily) != 2 {
+		t.Errorf("got %d days, want 2", len(single.Daily))
+	}
+	assertCost(t, "single total", single.Totals.TotalCost, 4)
+}
@@ -0,0 +1,154 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SubstitutionTarget represents a model that takes over a share of another model's tokens
+type SubstitutionTarget struct {
+	Model string  `json:"model"`
+	Share float64 `json:"share"` // Fraction of the tokens, 0 to 1
+}
+
+// SubstitutionRule represents moving the tokens of a model to other models. Shares below 100%
+// in total leave the rest with the original model.
+type SubstitutionRule struct {
+	From string               `json:"from"` // Raw model ID, undated ID such as claude-opus-4 or family such as opus
+	To   []SubstitutionTarget `json:"to"`
+}
+
+// ParseSubstitutionRule parses a rule such as opus=claude-sonnet-4, or with a percentage split
+// such as opus=claude-sonnet-4:70,claude-haiku-4-5:30
+func ParseSubstitutionRule(value string) (SubstitutionRule, error) {
+	from, to, ok := strings.Cut(value, "=")
+	if !ok || strings.TrimSpace(from) == "" || strings.TrimSpace(to) == "" {
+		return SubstitutionRule{}, fmt.Errorf("invalid substitution rule %q (expected FROM=TO or FROM=TO:PERCENT,...)", value)
+	}
+	rule := SubstitutionRule{From: strings.TrimSpace(from)}
+
+	targets := strings.Split(to, ",")
+	var total float64
+	for _, target := range targets {
+		model, percent, split := strings.Cut(strings.TrimSpace(target), ":")
+		share := 1.0
+		if split {
+			parsed, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(percent), "%"), 64)
+			// Written so that NaN fails too
+			if err != nil || !(parsed > 0) || math.IsInf(parsed, 0) {
+				return SubstitutionRule{}, fmt.Errorf("invalid share %q in substitution rule %q", percent, value)
+			}
+			share = parsed / 100
+		} else if len(targets) > 1 {
+			return SubstitutionRule{}, fmt.Errorf("substitution rule %q splits between several models, give each a percentage", value)
+		}
+		if strings.TrimSpace(model) == "" {
+			return SubstitutionRule{}, fmt.Errorf("invalid substitution rule %q: missing target model", value)
+		}
+		rule.To = append(rule.To, SubstitutionTarget{Model: strings.TrimSpace(model), Share: share})
+		total += share
+	}
+	// Allow for rounding in splits such as 33.3/33.3/33.4
+	if total > 1.0001 {
+		return SubstitutionRule{}, fmt.Errorf("substitution rule %q moves %.1f%% of the tokens, at most 100%% can move", value, total*100)
+	}
+	return rule, nil
+}
+
+// Matches reports whether the rule applies to a raw model ID: by the ID itself, with or without
+// provider prefix and snapshot date, or by the model's family
+func (r *SubstitutionRule) Matches(modelName string) bool {
+	from := strings.ToLower(r.From)
+	info := LookupModel(modelName)
+	return from == strings.ToLower(modelName) ||
+		from == info.ID ||
+		from == strings.ToLower(info.DisplayName) ||
+		(info.Family != ModelFamilyOther && from == strings.ToLower(string(info.Family)))
+}
+
+// SimulatedDay represents the cost of a day as used and under the substitution rules
+type SimulatedDay struct {
+	Date          string  `json:"date"`
+	ActualCost    float64 `json:"actualCost"`
+	SimulatedCost float64 `json:"simulatedCost"`
+	Delta         float64 `json:"delta"` // Simulated cost minus actual cost
+}
+
+// CostSimulation represents what the usage would have cost under substitution rules
+type CostSimulation struct {
+	Rules         []SubstitutionRule `json:"rules"`
+	Days          []SimulatedDay     `json:"days"`
+	ActualCost    float64            `json:"actualCost"`
+	SimulatedCost float64            `json:"simulatedCost"`
+	Delta         float64            `json:"delta"`
+	// Model breakdowns without pricing, counted at their reported cost in both scenarios
+	Unpriced int `json:"unpriced"`
+}
+
+// Simulate reprices every model breakdown from its token counts as used and with the tokens moved
+// by the first matching rule. Both scenarios use the same prices, so the delta shows the effect of
+// the substitution alone. Every target model must have pricing.
+func (r *CostResponse) Simulate(rules []SubstitutionRule, lookup PricingLookup) (*CostSimulation, error) {
+	targets := make(map[string]*ModelPricing)
+	for _, rule := range rules {
+		for _, target := range rule.To {
+			pricing, err := lookup(target.Model)
+			if err != nil {
+				return nil, fmt.Errorf("cannot substitute %s: %w", target.Model, err)
+			}
+			targets[target.Model] = pricing
+		}
+	}
+
+	simulation := &CostSimulation{Rules: rules, Days: make([]SimulatedDay, 0, len(r.Daily))}
+	for _, day := range r.Daily {
+		simulated := SimulatedDay{Date: day.Date}
+		if len(day.ModelBreakdowns) == 0 {
+			// Without breakdowns there are no tokens per model to move
+			simulated.ActualCost = day.TotalCost
+			simulated.SimulatedCost = day.TotalCost
+		}
+		for i := range day.ModelBreakdowns {
+			breakdown := &day.ModelBreakdowns[i]
+			actual := breakdown.Cost
+			if pricing, err := lookup(breakdown.ModelName); err == nil {
+				actual = pricing.CalculateBreakdownCost(breakdown)
+			} else {
+				simulation.Unpriced++
+			}
+			simulated.ActualCost += actual
+
+			rule := matchingRule(rules, breakdown.ModelName)
+			if rule == nil {
+				simulated.SimulatedCost += actual
+				continue
+			}
+			kept := 1.0
+			for _, target := range rule.To {
+				simulated.SimulatedCost += target.Share * targets[target.Model].CalculateBreakdownCost(breakdown)
+				kept -= target.Share
+			}
+			if kept > 0 {
+				simulated.SimulatedCost += kept * actual
+			}
+		}
+		simulated.Delta = simulated.SimulatedCost - simulated.ActualCost
+		simulation.Days = append(simulation.Days, simulated)
+		simulation.ActualCost += simulated.ActualCost
+		simulation.SimulatedCost += simulated.SimulatedCost
+	}
+	simulation.Delta = simulation.SimulatedCost - simulation.ActualCost
+	return simulation, nil
+}
+
+// matchingRule returns the first rule that applies to a model, or nil
+func matchingRule(rules []SubstitutionRule, modelName string) *SubstitutionRule {
+	for i := range rules {
+		if rules[i].Matches(modelName) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package entities
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseSubstitutionRule(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []SubstitutionTarget // nil when the rule is invalid
+	}{
+		{"opus=claude-sonnet-4", []SubstitutionTarget{{"claude-sonnet-4", 1}}},
+		{" opus = claude-sonnet-4:50% ", []SubstitutionTarget{{"claude-sonnet-4", 0.5}}},
+		{"opus=claude-sonnet-4:70,claude-haiku-4-5:30", []SubstitutionTarget{{"claude-sonnet-4", 0.7}, {"claude-haiku-4-5", 0.3}}},
+		{"opus=a:33.3,b:33.3,c:33.4", []SubstitutionTarget{{"a", 0.333}, {"b", 0.333}, {"c", 0.334}}},
+		{"opus=claude-sonnet-4:20,claude-haiku-4-5:30", []SubstitutionTarget{{"claude-sonnet-4", 0.2}, {"claude-haiku-4-5", 0.3}}},
+		// More than 100% in total
+		{"opus=claude-sonnet-4:70,claude-haiku-4-5:40", nil},
+		{"opus=claude-sonnet-4:150", nil},
+		// Splits need a percentage for every model
+		{"opus=claude-sonnet-4,claude-haiku-4-5", nil},
+		{"opus=claude-sonnet-4:70,claude-haiku-4-5", nil},
+		// Invalid shares and missing parts
+		{"opus=claude-sonnet-4:0", nil},
+		{"opus=claude-sonnet-4:-10", nil},
+		{"opus=claude-sonnet-4:half", nil},
+		{"opus=claude-sonnet-4:NaN", nil},
+		{"opus=claude-sonnet-4:Inf", nil},
+		{"opus=:50", nil},
+		{"opus", nil},
+		{"=claude-sonnet-4", nil},
+		{"opus=", nil},
+	}
+
+	for _, tt := range tests {
+		rule, err := ParseSubstitutionRule(tt.value)
+		if tt.want == nil {
+			if err == nil {
+				t.Errorf("ParseSubstitutionRule(%q): got %+v, want an error", tt.value, rule)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseSubstitutionRule(%q): %v", tt.value, err)
+			continue
+		}
+		if rule.From != "opus" || len(rule.To) != len(tt.want) {
+			t.Errorf("ParseSubstitutionRule(%q): got %+v, want opus to %+v", tt.value, rule, tt.want)
+			continue
+		}
+		for i, want := range tt.want {
+			if rule.To[i].Model != want.Model {
+				t.Errorf("ParseSubstitutionRule(%q) target %d: got %s, want %s", tt.value, i, rule.To[i].Model, want.Model)
+			}
+			assertCost(t, tt.value, rule.To[i].Share, want.Share)
+		}
+	}
+}
+
+// testPricing looks up round per-token prices for opus, sonnet and haiku models
+func testPricing(modelName string) (*ModelPricing, error) {
+	switch LookupModel(modelName).Family {
+	case ModelFamilyOpus:
+		return &ModelPricing{InputCostPerToken: 0.5, OutputCostPerToken: 2.5}, nil
+	case ModelFamilySonnet:
+		return &ModelPricing{InputCostPerToken: 0.1, OutputCostPerToken: 0.5}, nil
+	case ModelFamilyHaiku:
+		return &ModelPricing{InputCostPerToken: 0.05, OutputCostPerToken: 0.25}, nil
+	default:
+		return nil, fmt.Errorf("no pricing for %s", modelName)
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	// 10 input and 2 output tokens cost 10 on Opus, 2 on Sonnet and 1 on Haiku. The reported
+	// cost is out of date, both scenarios reprice from tokens.
+	response := &CostResponse{Daily: []DailyUsage{
+		{Date: "2025-03-01", TotalCost: 1002, ModelBreakdowns: []ModelBreakdown{
+			{ModelName: "claude-opus-4-20250514", InputTokens: 10, OutputTokens: 2, Cost: 999},
+			{ModelName: "gpt-4o", InputTokens: 10, OutputTokens: 2, Cost: 3},
+		}},
+		{Date: "2025-03-02", TotalCost: 4},
+	}}
+
+	rule := func(value string) SubstitutionRule {
+		t.Helper()
+		rule, err := ParseSubstitutionRule(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rule
+	}
+
+	tests := []struct {
+		name      string
+		rules     []SubstitutionRule
+		simulated float64 // Of the first day
+	}{
+		{"no rules", nil, 13},
+		{"whole model", []SubstitutionRule{rule("opus=claude-sonnet-4")}, 2 + 3},
+		// The kept half is repriced at Opus prices, not taken from the reported cost
+		{"kept share", []SubstitutionRule{rule("opus=claude-sonnet-4:50")}, 0.5*2 + 0.5*10 + 3},
+		{"split", []SubstitutionRule{rule("opus=claude-sonnet-4:70,claude-haiku-4-5:30")}, 0.7*2 + 0.3*1 + 3},
+		{"first rule wins", []SubstitutionRule{rule("claude-opus-4=claude-haiku-4-5"), rule("opus=claude-sonnet-4")}, 1 + 3},
+		{"no match", []SubstitutionRule{rule("haiku=claude-sonnet-4")}, 13},
+	}
+
+	for _, tt := range tests {
+		simulation, err := response.Simulate(tt.rules, testPricing)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if len(simulation.Days) != 2 {
+			t.Fatalf("%s: got %d days, want 2", tt.name, len(simulation.Days))
+		}
+		// Unpriced models count at their reported cost in both scenarios
+		if simulation.Unpriced != 1 {
+			t.Errorf("%s: got %d unpriced breakdowns, want 1", tt.name, simulation.Unpriced)
+		}
+		first := simulation.Days[0]
+		assertCost(t, tt.name+" actual", first.ActualCost, 13)
+		assertCost(t, tt.name+" simulated", first.SimulatedCost, tt.simulated)
+		assertCost(t, tt.name+" delta", first.Delta, tt.simulated-13)
+		// A day without breakdowns has no tokens to move
+		assertCost(t, tt.name+" without breakdowns", simulation.Days[1].SimulatedCost, 4)
+		assertCost(t, tt.name+" total", simulation.SimulatedCost, tt.simulated+4)
+		assertCost(t, tt.name+" total delta", simulation.Delta, tt.simulated-13)
+	}
+
+	if _, err := response.Simulate([]SubstitutionRule{rule("opus=gpt-4o")}, testPricing); err == nil {
+		t.Error("expected an error for a target without pricing")
+	}
+}
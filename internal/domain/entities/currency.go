@@ -0,0 +1,148 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// CurrencyUSD is the currency ccusage reports costs in
+const CurrencyUSD = "USD"
+
+// maxCurrencyDecimals is the most decimals a currency format may have; more would overflow the
+// whole units Format rounds to
+const maxCurrencyDecimals = 6
+
+// Currency represents how amounts in a currency are written
+type Currency struct {
+	Code             string `json:"-"`
+	Symbol           string `json:"symbol"`
+	SymbolAfter      bool   `json:"symbolAfter"` // Write the symbol after the amount, e.g. 12,50€
+	Decimals         int    `json:"decimals"`
+	GroupSeparator   string `json:"groupSeparator"`
+	DecimalSeparator string `json:"decimalSeparator"`
+}
+
+// builtinCurrencies are the currencies that can be formatted without any configuration
+var builtinCurrencies = map[string]Currency{
+	"USD": {Code: "USD", Symbol: "$", Decimals: 2, GroupSeparator: ",", DecimalSeparator: "."},
+	"EUR": {Code: "EUR", Symbol: "€", SymbolAfter: true, Decimals: 2, GroupSeparator: ".", DecimalSeparator: ","},
+	"GBP": {Code: "GBP", Symbol: "£", Decimals: 2, GroupSeparator: ",", DecimalSeparator: "."},
+	"JPY": {Code: "JPY", Symbol: "¥", Decimals: 0, GroupSeparator: ",", DecimalSeparator: "."},
+}
+
+// LookupCurrency returns the built-in format of a currency code such as "EUR"
+func LookupCurrency(code string) (Currency, bool) {
+	currency, ok := builtinCurrencies[strings.ToUpper(code)]
+	return currency, ok
+}
+
+// Format writes an amount in this currency, e.g. $1,234.56, 1.234,56€ or ¥1,235. Amounts that
+// round to zero have no sign.
+func (c Currency) Format(amount float64) string {
+	scale := math.Pow10(c.Decimals)
+	units := int64(math.Round(math.Abs(amount) * scale))
+	sign := ""
+	if amount < 0 && units > 0 {
+		sign = "-"
+	}
+	whole := units / int64(scale)
+
+	number := groupDigits(strconv.FormatInt(whole, 10), c.GroupSeparator)
+	if c.Decimals > 0 {
+		fraction := units % int64(scale)
+		number += c.DecimalSeparator + fmt.Sprintf("%0*d", c.Decimals, fraction)
+	}
+
+	if c.SymbolAfter {
+		return sign + number + c.Symbol
+	}
+	return sign + c.Symbol + number
+}
+
+// groupDigits inserts the separator between every three digits from the right
+func groupDigits(digits, separator string) string {
+	if separator == "" || len(digits) <= 3 {
+		return digits
+	}
+	var builder strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		builder.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if builder.Len() > 0 {
+			builder.WriteString(separator)
+		}
+		builder.WriteString(digits[i : i+3])
+	}
+	return builder.String()
+}
+
+// ExchangeRates represents exchange rates relative to a base currency, as read from a rate file
+type ExchangeRates struct {
+	Base       string              `json:"base"`       // Defaults to USD
+	Rates      map[string]float64  `json:"rates"`      // Units of each currency per unit of Base
+	Currencies map[string]Currency `json:"currencies"` // Formats of currencies that are not built in, or overrides
+}
+
+// RateFromUSD returns how many units of the currency one US dollar buys
+func (r *ExchangeRates) RateFromUSD(code string) (float64, error) {
+	code = strings.ToUpper(code)
+	base := strings.ToUpper(r.Base)
+	if base == "" {
+		base = CurrencyUSD
+	}
+
+	rate := func(currency string) (float64, error) {
+		if currency == base {
+			return 1, nil
+		}
+		for name, value := range r.Rates {
+			if strings.ToUpper(name) == currency {
+				if value <= 0 {
+					return 0, fmt.Errorf("exchange rate of %s must be positive, got %v", currency, value)
+				}
+				return value, nil
+			}
+		}
+		return 0, fmt.Errorf("no exchange rate for %s (base %s)", currency, base)
+	}
+
+	target, err := rate(code)
+	if err != nil {
+		return 0, err
+	}
+	// Rates against another base are converted through the dollar's rate
+	usd, err := rate(CurrencyUSD)
+	if err != nil {
+		return 0, err
+	}
+	return target / usd, nil
+}
+
+// Validate checks that the currency formats of the rate file can be written
+func (r *ExchangeRates) Validate() error {
+	for name, currency := range r.Currencies {
+		if currency.Decimals < 0 || currency.Decimals > maxCurrencyDecimals {
+			return fmt.Errorf("currency %s: decimals must be between 0 and %d, got %d", strings.ToUpper(name), maxCurrencyDecimals, currency.Decimals)
+		}
+	}
+	return nil
+}
+
+// Currency returns the format of a currency, preferring the rate file's over the built-in one
+func (r *ExchangeRates) Currency(code string) (Currency, error) {
+	code = strings.ToUpper(code)
+	for name, currency := range r.Currencies {
+		if strings.ToUpper(name) == code {
+			currency.Code = code
+			return currency, nil
+		}
+	}
+	if currency, ok := LookupCurrency(code); ok {
+		return currency, nil
+	}
+	return Currency{}, fmt.Errorf("unknown currency %s: add its format under \"currencies\" in the rate file", code)
+}
@@ -0,0 +1,135 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCurrencyFormat(t *testing.T) {
+	usd, _ := LookupCurrency("usd")
+	eur, _ := LookupCurrency("EUR")
+	jpy, _ := LookupCurrency("JPY")
+	plain := Currency{Code: "XTS", Symbol: " XTS", SymbolAfter: true, Decimals: 3, DecimalSeparator: "."}
+
+	tests := []struct {
+		currency Currency
+		amount   float64
+		want     string
+	}{
+		{usd, 0, "$0.00"},
+		{usd, 12.5, "$12.50"},
+		// Rounding to the nearest cent, also into the next whole unit
+		{usd, 0.004, "$0.00"},
+		{usd, 0.006, "$0.01"},
+		{usd, 9.996, "$10.00"},
+		// Grouping by thousands
+		{usd, 999.99, "$999.99"},
+		{usd, 1234.56, "$1,234.56"},
+		{usd, 1234567.891, "$1,234,567.89"},
+		{eur, 1234.5, "1.234,50€"},
+		// Zero decimals round to whole yen
+		{jpy, 1234.5, "¥1,235"},
+		{jpy, 999.4, "¥999"},
+		// Negative amounts, but no sign on one that rounds to zero
+		{usd, -1234.5, "-$1,234.50"},
+		{eur, -0.5, "-0,50€"},
+		{usd, -0.001, "$0.00"},
+		{plain, 1234.5678, "1234.568 XTS"},
+	}
+	for _, tt := range tests {
+		if got := tt.currency.Format(tt.amount); got != tt.want {
+			t.Errorf("%s %v: got %q, want %q", tt.currency.Code, tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestRateFromUSD(t *testing.T) {
+	tests := []struct {
+		name  string
+		rates ExchangeRates
+		code  string
+		want  float64 // 0 when an error is expected
+	}{
+		{"dollar base", ExchangeRates{Rates: map[string]float64{"EUR": 0.92}}, "eur", 0.92},
+		{"dollar itself", ExchangeRates{Rates: map[string]float64{"EUR": 0.92}}, "USD", 1},
+		// Rates against another base go through the dollar's rate
+		{"euro base", ExchangeRates{Base: "eur", Rates: map[string]float64{"usd": 1.08, "JPY": 162}}, "JPY", 150},
+		{"base itself", ExchangeRates{Base: "EUR", Rates: map[string]float64{"USD": 1.25}}, "EUR", 0.8},
+		{"missing rate", ExchangeRates{Rates: map[string]float64{"EUR": 0.92}}, "GBP", 0},
+		{"missing dollar rate", ExchangeRates{Base: "EUR", Rates: map[string]float64{"JPY": 162}}, "JPY", 0},
+		{"zero rate", ExchangeRates{Rates: map[string]float64{"EUR": 0}}, "EUR", 0},
+		{"negative rate", ExchangeRates{Rates: map[string]float64{"EUR": -1}}, "EUR", 0},
+	}
+	for _, tt := range tests {
+		rate, err := tt.rates.RateFromUSD(tt.code)
+		if tt.want == 0 {
+			if err == nil {
+				t.Errorf("%s: got rate %v, want an error", tt.name, rate)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		assertCost(t, tt.name, rate, tt.want)
+	}
+}
+
+func TestExchangeRatesValidate(t *testing.T) {
+	tests := []struct {
+		decimals int
+		valid    bool
+	}{
+		{0, true},
+		{2, true},
+		{maxCurrencyDecimals, true},
+		{-1, false},
+		{maxCurrencyDecimals + 1, false},
+		{400, false},
+	}
+	for _, tt := range tests {
+		rates := ExchangeRates{Currencies: map[string]Currency{"chf": {Symbol: "CHF ", Decimals: tt.decimals}}}
+		err := rates.Validate()
+		if tt.valid && err != nil {
+			t.Errorf("decimals %d: %v", tt.decimals, err)
+		}
+		if !tt.valid && (err == nil || !strings.Contains(err.Error(), "CHF")) {
+			t.Errorf("decimals %d: got error %v, want one naming CHF", tt.decimals, err)
+		}
+	}
+}
+
+func TestExchangeRatesCurrency(t *testing.T) {
+	rates := ExchangeRates{Currencies: map[string]Currency{
+		"chf": {Symbol: "CHF ", Decimals: 2, GroupSeparator: "'", DecimalSeparator: "."},
+		"JPY": {Symbol: "円", SymbolAfter: true, GroupSeparator: ","},
+	}}
+
+	tests := []struct {
+		code string
+		want string // Formatted 1234.5, empty when the currency is unknown
+	}{
+		{"CHF", "CHF 1'234.50"},
+		// The rate file's format wins over the built-in one
+		{"jpy", "1,235円"},
+		{"GBP", "£1,234.50"},
+		{"XYZ", ""},
+	}
+	for _, tt := range tests {
+		currency, err := rates.Currency(tt.code)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("%s: got %+v, want an error", tt.code, currency)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.code, err)
+			continue
+		}
+		if got := currency.Format(1234.5); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.code, got, tt.want)
+		}
+	}
+}
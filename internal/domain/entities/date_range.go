@@ -0,0 +1,108 @@
+package entities
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateLayout is the layout of DailyUsage.Date
+const dateLayout = "2006-01-02"
+
+// DateRange represents an inclusive range of dates in YYYY-MM-DD form; empty bounds are open
+type DateRange struct {
+	Since string
+	Until string
+}
+
+// IsEmpty returns true if neither bound is set
+func (r DateRange) IsEmpty() bool {
+	return r.Since == "" && r.Until == ""
+}
+
+// Contains returns true if the date (YYYY-MM-DD) falls within the range
+func (r DateRange) Contains(date string) bool {
+	return (r.Since == "" || date >= r.Since) && (r.Until == "" || date <= r.Until)
+}
+
+// Including returns the range widened to also cover since through until; open bounds stay open
+func (r DateRange) Including(since, until string) DateRange {
+	if r.Since != "" && since < r.Since {
+		r.Since = since
+	}
+	if r.Until != "" && until > r.Until {
+		r.Until = until
+	}
+	return r
+}
+
+// ParseDateRange parses the values of the range's start and end relative to now.
+// Besides YYYY-MM-DD and YYYYMMDD it accepts "today", "yesterday", "Nd" (the last N days
+// including today), "this-week", "this-month" and "last-month". A period such as "last-month"
+// starts the range at the beginning of the period when used as since and ends it at the end
+// of the period when used as until. "Nd" as until ends the range the day before the last N
+// days, so since 14d and until 7d is the week before since 7d.
+func ParseDateRange(since, until string, now time.Time) (DateRange, error) {
+	var dateRange DateRange
+	if since != "" {
+		start, _, err := parseDateBound(since, now)
+		if err != nil {
+			return DateRange{}, fmt.Errorf("invalid start date: %w", err)
+		}
+		dateRange.Since = start.Format(dateLayout)
+	}
+	if until != "" {
+		_, end, err := parseDateBound(until, now)
+		if err != nil {
+			return DateRange{}, fmt.Errorf("invalid end date: %w", err)
+		}
+		dateRange.Until = end.Format(dateLayout)
+	}
+	if dateRange.Since != "" && dateRange.Until != "" && dateRange.Since > dateRange.Until {
+		return DateRange{}, fmt.Errorf("start date %s is after end date %s", dateRange.Since, dateRange.Until)
+	}
+	return dateRange, nil
+}
+
+// parseDateBound returns the first and last day of the period a value refers to
+func parseDateBound(value string, now time.Time) (time.Time, time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	switch value {
+	case "today":
+		return today, today, nil
+	case "yesterday":
+		yesterday := today.AddDate(0, 0, -1)
+		return yesterday, yesterday, nil
+	case "this-week":
+		// Weeks start on Monday
+		start := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+		return start, start.AddDate(0, 0, 6), nil
+	case "this-month":
+		start := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
+		return start, start.AddDate(0, 1, -1), nil
+	case "last-month":
+		start := time.Date(today.Year(), today.Month()-1, 1, 0, 0, 0, 0, today.Location())
+		return start, start.AddDate(0, 1, -1), nil
+	}
+
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 1 {
+			return time.Time{}, time.Time{}, fmt.Errorf("%q is not a positive number of days", value)
+		}
+		// The last N days start N-1 days ago, the days before them end N days ago
+		start := today.AddDate(0, 0, -(n - 1))
+		return start, start.AddDate(0, 0, -1), nil
+	}
+
+	for _, layout := range []string{dateLayout, "20060102"} {
+		if date, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return date, date, nil
+		}
+	}
+
+	return time.Time{}, time.Time{}, fmt.Errorf("%q is not a date (YYYY-MM-DD) or one of today, yesterday, Nd, this-week, this-month, last-month", value)
+}
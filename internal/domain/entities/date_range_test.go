@@ -0,0 +1,116 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDateRange(t *testing.T) {
+	// A Thursday
+	now := time.Date(2026, 10, 15, 13, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		since, until string
+		want         DateRange
+	}{
+		{"", "", DateRange{}},
+		{"2026-09-01", "2026-09-30", DateRange{Since: "2026-09-01", Until: "2026-09-30"}},
+		{"20260901", "", DateRange{Since: "2026-09-01"}},
+		{"today", "today", DateRange{Since: "2026-10-15", Until: "2026-10-15"}},
+		{" Yesterday ", "yesterday", DateRange{Since: "2026-10-14", Until: "2026-10-14"}},
+		// The last 7 days including today
+		{"7d", "", DateRange{Since: "2026-10-09"}},
+		{"1d", "", DateRange{Since: "2026-10-15"}},
+		// As until, the days before the last N days
+		{"", "7d", DateRange{Until: "2026-10-08"}},
+		{"14d", "7d", DateRange{Since: "2026-10-02", Until: "2026-10-08"}},
+		{"", "1d", DateRange{Until: "2026-10-14"}},
+		// Weeks run Monday to Sunday
+		{"this-week", "this-week", DateRange{Since: "2026-10-12", Until: "2026-10-18"}},
+		{"this-month", "this-month", DateRange{Since: "2026-10-01", Until: "2026-10-31"}},
+		{"last-month", "last-month", DateRange{Since: "2026-09-01", Until: "2026-09-30"}},
+		{"last-month", "today", DateRange{Since: "2026-09-01", Until: "2026-10-15"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseDateRange(tt.since, tt.until, now)
+		if err != nil {
+			t.Errorf("%q to %q: %v", tt.since, tt.until, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%q to %q: got %+v, want %+v", tt.since, tt.until, got, tt.want)
+		}
+	}
+}
+
+func TestParseDateRangeMonthBoundaries(t *testing.T) {
+	tests := []struct {
+		now   time.Time
+		value string
+		want  DateRange
+	}{
+		// Last month of January is December of the year before
+		{time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC), "last-month", DateRange{Since: "2025-12-01", Until: "2025-12-31"}},
+		// This week of a Monday and of a Sunday
+		{time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), "this-week", DateRange{Since: "2026-10-12", Until: "2026-10-18"}},
+		{time.Date(2026, 10, 18, 23, 59, 0, 0, time.UTC), "this-week", DateRange{Since: "2026-10-12", Until: "2026-10-18"}},
+		{time.Date(2028, 2, 10, 0, 0, 0, 0, time.UTC), "this-month", DateRange{Since: "2028-02-01", Until: "2028-02-29"}},
+		{time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), "yesterday", DateRange{Since: "2026-02-28", Until: "2026-02-28"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseDateRange(tt.value, tt.value, tt.now)
+		if err != nil {
+			t.Errorf("%s on %s: %v", tt.value, tt.now.Format(dateLayout), err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s on %s: got %+v, want %+v", tt.value, tt.now.Format(dateLayout), got, tt.want)
+		}
+	}
+}
+
+func TestParseDateRangeErrors(t *testing.T) {
+	now := time.Date(2026, 10, 15, 13, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		since, until string
+		want         string
+	}{
+		{"2026-10-16", "2026-10-15", "start date 2026-10-16 is after end date 2026-10-15"},
+		{"today", "yesterday", "is after end date"},
+		// The last 7 days start after the days before them end
+		{"7d", "7d", "start date 2026-10-09 is after end date 2026-10-08"},
+		{"0d", "", "invalid start date"},
+		{"", "-3d", "invalid end date"},
+		{"xd", "", "not a positive number of days"},
+		{"2026-13-01", "", "not a date"},
+		{"", "next-month", "not a date"},
+	}
+	for _, tt := range tests {
+		_, err := ParseDateRange(tt.since, tt.until, now)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%q to %q: got error %v, want one containing %q", tt.since, tt.until, err, tt.want)
+		}
+	}
+}
+
+func TestDateRangeIncluding(t *testing.T) {
+	tests := []struct {
+		dateRange    DateRange
+		since, until string
+		want         DateRange
+	}{
+		{DateRange{Since: "2026-10-05", Until: "2026-10-10"}, "2026-10-01", "2026-10-15", DateRange{Since: "2026-10-01", Until: "2026-10-15"}},
+		{DateRange{Since: "2026-10-05", Until: "2026-10-10"}, "2026-10-06", "2026-10-08", DateRange{Since: "2026-10-05", Until: "2026-10-10"}},
+		// Open bounds stay open
+		{DateRange{Since: "2026-10-05"}, "2026-10-01", "2026-10-15", DateRange{Since: "2026-10-01"}},
+		{DateRange{Until: "2026-10-10"}, "2026-10-01", "2026-10-15", DateRange{Until: "2026-10-15"}},
+		{DateRange{}, "2026-10-01", "2026-10-15", DateRange{}},
+	}
+	for _, tt := range tests {
+		if got := tt.dateRange.Including(tt.since, tt.until); got != tt.want {
+			t.Errorf("%+v including %s to %s: got %+v, want %+v", tt.dateRange, tt.since, tt.until, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package entities
+
+// ModelPricing represents the per-token prices of a model in USD
+type ModelPricing struct {
+	InputCostPerToken         float64 `json:"input_cost_per_token"`
+	OutputCostPerToken        float64 `json:"output_cost_per_token"`
+	CacheCreationCostPerToken float64 `json:"cache_creation_input_token_cost"`
+	CacheReadCostPerToken     float64 `json:"cache_read_input_token_cost"`
+}
+
+// CalculateCost calculates the cost of the given token counts
+func (p *ModelPricing) CalculateCost(inputTokens, outputTokens, cacheCreationTokens, cacheReadTokens int) float64 {
+	return float64(inputTokens)*p.InputCostPerToken +
+		float64(outputTokens)*p.OutputCostPerToken +
+		float64(cacheCreationTokens)*p.CacheCreationCostPerToken +
+		float64(cacheReadTokens)*p.CacheReadCostPerToken
+}
+
+// CalculateBreakdownCost calculates the cost of a model breakdown from its token counts
+func (p *ModelPricing) CalculateBreakdownCost(breakdown *ModelBreakdown) float64 {
+	return p.CalculateCost(breakdown.InputTokens, breakdown.OutputTokens, breakdown.CacheCreationTokens, breakdown.CacheReadTokens)
+}
+
+// CostDiscrepancy represents a difference between a reported cost and the cost recomputed from tokens
+type CostDiscrepancy struct {
+	Date         string
+	ModelName    string
+	ReportedCost float64
+	ComputedCost float64
+	Priced       bool
+}
+
+// Delta returns the computed cost minus the reported cost
+func (d *CostDiscrepancy) Delta() float64 {
+	return d.ComputedCost - d.ReportedCost
+}
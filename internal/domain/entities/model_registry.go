@@ -0,0 +1,149 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ModelFamily represents a line of Claude models
+type ModelFamily string
+
+// Model families; models that are not Claude or have no known family are Other
+const (
+	ModelFamilyOpus   ModelFamily = "Opus"
+	ModelFamilySonnet ModelFamily = "Sonnet"
+	ModelFamilyHaiku  ModelFamily = "Haiku"
+	ModelFamilyOther  ModelFamily = "Other"
+)
+
+// modelFamilies maps the family token of a model ID to its family
+var modelFamilies = map[string]ModelFamily{
+	"opus":   ModelFamilyOpus,
+	"sonnet": ModelFamilySonnet,
+	"haiku":  ModelFamilyHaiku,
+}
+
+var (
+	// modelSnapshot matches the release date that ends dated model IDs such as claude-sonnet-4-20250514
+	modelSnapshot = regexp.MustCompile(`-(\d{4})(\d{2})(\d{2})$`)
+	// modelRevision matches the revision Bedrock and Vertex AI add to model IDs, e.g. -v1:0 or -v2
+	modelRevision = regexp.MustCompile(`-v\d+(:\d+)?$`)
+	// modelVersionToken matches a version part of a model ID, e.g. 3 or 2.1
+	modelVersionToken = regexp.MustCompile(`^\d{1,2}(\.\d+)?$`)
+)
+
+// ModelInfo represents what is known about a model from its raw ID
+type ModelInfo struct {
+	ID          string      // Raw ID without provider prefix, snapshot date or revision, e.g. claude-sonnet-4
+	Family      ModelFamily // Other when the ID is not a recognized Claude model
+	Version     string      // e.g. 4 or 3.5, empty when unknown
+	Snapshot    string      // Release date as YYYY-MM-DD, empty for undated IDs
+	DisplayName string      // e.g. Sonnet 4 or Sonnet 3.5; the raw ID when the model is not recognized
+}
+
+// LookupModel parses a raw model ID such as claude-sonnet-4-20250514, claude-3-5-haiku-20241022,
+// anthropic.claude-3-opus-20240229-v1:0 or claude-3-5-sonnet@20240620. IDs that are not
+// recognized keep their raw name as display name and belong to no family.
+func LookupModel(name string) ModelInfo {
+	unknown := ModelInfo{ID: name, Family: ModelFamilyOther, DisplayName: name}
+
+	id := strings.ToLower(strings.TrimSpace(name))
+	if i := strings.LastIndex(id, "/"); i >= 0 {
+		id = id[i+1:]
+	}
+	id = strings.TrimPrefix(id, "anthropic.")
+	// Vertex AI separates the snapshot with @ instead of -
+	id = strings.Replace(id, "@", "-", 1)
+	id = strings.TrimSuffix(id, "-latest")
+
+	// Bedrock puts the revision after the snapshot, Vertex AI before it
+	id = modelRevision.ReplaceAllString(id, "")
+	info := ModelInfo{Family: ModelFamilyOther}
+	if match := modelSnapshot.FindStringSubmatch(id); match != nil {
+		info.Snapshot = match[1] + "-" + match[2] + "-" + match[3]
+		id = strings.TrimSuffix(id, match[0])
+	}
+	id = modelRevision.ReplaceAllString(id, "")
+	if !strings.HasPrefix(id, "claude-") {
+		return unknown
+	}
+	info.ID = id
+
+	// Older IDs put the version before the family (claude-3-5-sonnet), newer ones after (claude-sonnet-4-5)
+	var version []string
+	for _, token := range strings.Split(strings.TrimPrefix(id, "claude-"), "-") {
+		if family, ok := modelFamilies[token]; ok && info.Family == ModelFamilyOther {
+			info.Family = family
+			continue
+		}
+		if !modelVersionToken.MatchString(token) {
+			return unknown
+		}
+		version = append(version, token)
+	}
+	info.Version = strings.Join(version, ".")
+
+	switch {
+	case info.Family != ModelFamilyOther && info.Version != "":
+		info.DisplayName = string(info.Family) + " " + info.Version
+	case info.Family != ModelFamilyOther:
+		info.DisplayName = string(info.Family)
+	case info.Version != "":
+		info.DisplayName = "Claude " + info.Version
+	default:
+		return unknown
+	}
+	return info
+}
+
+// ModelGrouping represents how model breakdowns are grouped for display
+type ModelGrouping string
+
+// Model groupings
+const (
+	ModelGroupingModel  ModelGrouping = "model"  // One group per model, snapshots of the same model merged
+	ModelGroupingFamily ModelGrouping = "family" // One group per family, e.g. every Sonnet version
+)
+
+// ParseModelGrouping parses a model grouping name
+func ParseModelGrouping(value string) (ModelGrouping, error) {
+	switch grouping := ModelGrouping(strings.ToLower(value)); grouping {
+	case ModelGroupingModel, ModelGroupingFamily:
+		return grouping, nil
+	default:
+		return "", fmt.Errorf("unknown model grouping %q (expected %s or %s)", value, ModelGroupingModel, ModelGroupingFamily)
+	}
+}
+
+// Label returns the name of the group a raw model ID belongs to. Unrecognized models form
+// groups of their own, so unrelated models never merge into Other.
+func (g ModelGrouping) Label(modelName string) string {
+	info := LookupModel(modelName)
+	if g == ModelGroupingFamily && info.Family != ModelFamilyOther {
+		return string(info.Family)
+	}
+	return info.DisplayName
+}
+
+// GroupModels returns a copy of the daily data with the model breakdowns and models used of
+// every day merged into the groups of the grouping
+func (r *CostResponse) GroupModels(grouping ModelGrouping) *CostResponse {
+	grouped := &CostResponse{Daily: make([]DailyUsage, len(r.Daily)), Totals: r.Totals}
+	for i, day := range r.Daily {
+		breakdowns := make([]ModelBreakdown, len(day.ModelBreakdowns))
+		for j, breakdown := range day.ModelBreakdowns {
+			breakdown.ModelName = grouping.Label(breakdown.ModelName)
+			breakdowns[j] = breakdown
+		}
+		names := make([]string, len(day.ModelsUsed))
+		for j, name := range day.ModelsUsed {
+			names[j] = grouping.Label(name)
+		}
+
+		day.ModelBreakdowns = mergeModelBreakdowns(nil, breakdowns)
+		day.ModelsUsed = mergeModelNames(nil, names)
+		grouped.Daily[i] = day
+	}
+	return grouped
+}
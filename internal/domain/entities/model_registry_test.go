@@ -0,0 +1,57 @@
+package entities
+
+import "testing"
+
+func TestLookupModel(t *testing.T) {
+	tests := []struct {
+		name string
+		want ModelInfo
+	}{
+		// Dated and undated Anthropic API IDs
+		{"claude-sonnet-4-20250514", ModelInfo{"claude-sonnet-4", ModelFamilySonnet, "4", "2025-05-14", "Sonnet 4"}},
+		{"claude-opus-4-1-20250805", ModelInfo{"claude-opus-4-1", ModelFamilyOpus, "4.1", "2025-08-05", "Opus 4.1"}},
+		{"claude-sonnet-4-5", ModelInfo{"claude-sonnet-4-5", ModelFamilySonnet, "4.5", "", "Sonnet 4.5"}},
+		{"Claude-Haiku-4-5 ", ModelInfo{"claude-haiku-4-5", ModelFamilyHaiku, "4.5", "", "Haiku 4.5"}},
+		// Older IDs put the version before the family
+		{"claude-3-5-sonnet-20241022", ModelInfo{"claude-3-5-sonnet", ModelFamilySonnet, "3.5", "2024-10-22", "Sonnet 3.5"}},
+		{"claude-3-5-haiku-latest", ModelInfo{"claude-3-5-haiku", ModelFamilyHaiku, "3.5", "", "Haiku 3.5"}},
+		{"claude-2.1", ModelInfo{"claude-2.1", ModelFamilyOther, "2.1", "", "Claude 2.1"}},
+		// Bedrock revisions after the snapshot
+		{"anthropic.claude-3-opus-20240229-v1:0", ModelInfo{"claude-3-opus", ModelFamilyOpus, "3", "2024-02-29", "Opus 3"}},
+		{"anthropic.claude-sonnet-4-20250514-v1", ModelInfo{"claude-sonnet-4", ModelFamilySonnet, "4", "2025-05-14", "Sonnet 4"}},
+		// Vertex AI snapshots after @, revisions before it
+		{"claude-3-5-sonnet@20240620", ModelInfo{"claude-3-5-sonnet", ModelFamilySonnet, "3.5", "2024-06-20", "Sonnet 3.5"}},
+		{"claude-3-5-sonnet-v2@20241022", ModelInfo{"claude-3-5-sonnet", ModelFamilySonnet, "3.5", "2024-10-22", "Sonnet 3.5"}},
+		{"publishers/anthropic/models/claude-opus-4@20250514", ModelInfo{"claude-opus-4", ModelFamilyOpus, "4", "2025-05-14", "Opus 4"}},
+		// Unknown IDs keep their raw name
+		{"gpt-4o", ModelInfo{"gpt-4o", ModelFamilyOther, "", "", "gpt-4o"}},
+		{"<synthetic>", ModelInfo{"<synthetic>", ModelFamilyOther, "", "", "<synthetic>"}},
+		{"claude-instant-1.2", ModelInfo{"claude-instant-1.2", ModelFamilyOther, "", "", "claude-instant-1.2"}},
+		{"claude-", ModelInfo{"claude-", ModelFamilyOther, "", "", "claude-"}},
+	}
+
+	for _, tt := range tests {
+		if got := LookupModel(tt.name); got != tt.want {
+			t.Errorf("LookupModel(%q): got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestModelGroupingLabel(t *testing.T) {
+	tests := []struct {
+		grouping ModelGrouping
+		name     string
+		want     string
+	}{
+		{ModelGroupingModel, "claude-sonnet-4-20250514", "Sonnet 4"},
+		{ModelGroupingModel, "anthropic.claude-sonnet-4-20250514-v1:0", "Sonnet 4"},
+		{ModelGroupingFamily, "claude-3-5-sonnet-20241022", "Sonnet"},
+		{ModelGroupingFamily, "claude-2.1", "Claude 2.1"},
+		{ModelGroupingFamily, "gpt-4o", "gpt-4o"},
+	}
+	for _, tt := range tests {
+		if got := tt.grouping.Label(tt.name); got != tt.want {
+			t.Errorf("%s label of %q: got %q, want %q", tt.grouping, tt.name, got, tt.want)
+		}
+	}
+}
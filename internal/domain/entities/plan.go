@@ -0,0 +1,157 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// planPrices are the monthly USD prices of the Claude subscription plans
+var planPrices = map[string]float64{
+	"pro":     20,
+	"max-5x":  100,
+	"max-20x": 200,
+}
+
+// planNames are the display names of the Claude subscription plans
+var planNames = map[string]string{
+	"pro":     "Pro",
+	"max-5x":  "Max 5x",
+	"max-20x": "Max 20x",
+}
+
+// Plan represents a flat-rate subscription that API-equivalent costs are compared with
+type Plan struct {
+	Name         string  `json:"plan"`         // pro, max-5x, max-20x or custom
+	MonthlyPrice float64 `json:"monthlyPrice"` // USD; required for custom plans, looked up otherwise
+	BillingDay   int     `json:"billingDay"`   // Day of the month billing months start on, defaults to 1
+}
+
+// ParsePlan parses a plan name such as max-5x, or a custom monthly price in USD such as 50
+func ParsePlan(value string) (Plan, error) {
+	if price, err := strconv.ParseFloat(value, 64); err == nil {
+		plan := Plan{Name: "custom", MonthlyPrice: price}
+		return plan, plan.Validate()
+	}
+	plan := Plan{Name: value}
+	return plan, plan.Validate()
+}
+
+// Validate fills in the price and billing day and checks that the plan makes sense
+func (p *Plan) Validate() error {
+	p.Name = strings.ToLower(p.Name)
+	if p.Name == "" {
+		p.Name = "custom"
+	}
+	if price, ok := planPrices[p.Name]; ok {
+		if p.MonthlyPrice == 0 {
+			p.MonthlyPrice = price
+		}
+	} else if p.Name != "custom" {
+		return fmt.Errorf("unknown plan %q (expected pro, max-5x, max-20x or a monthly price)", p.Name)
+	}
+	// Written so that NaN fails too
+	if !(p.MonthlyPrice > 0) || math.IsInf(p.MonthlyPrice, 0) {
+		return fmt.Errorf("plan price must be a positive number, got %v", p.MonthlyPrice)
+	}
+
+	if p.BillingDay == 0 {
+		p.BillingDay = 1
+	}
+	if p.BillingDay < 1 || p.BillingDay > 31 {
+		return fmt.Errorf("billing day must be between 1 and 31, got %d", p.BillingDay)
+	}
+	return nil
+}
+
+// DisplayName returns the plan's name for display, e.g. "Max 5x" or "$50.00/month"
+func (p *Plan) DisplayName() string {
+	if name, ok := planNames[p.Name]; ok {
+		return name
+	}
+	return FormatCost(p.MonthlyPrice) + "/month"
+}
+
+// BillingMonthStart returns the first day of the billing month containing date; a billing day
+// past the end of a short month falls on its last day
+func (p *Plan) BillingMonthStart(date time.Time) time.Time {
+	start := p.billingDayIn(date.Year(), date.Month(), date.Location())
+	if date.Before(start) {
+		previous := time.Date(date.Year(), date.Month()-1, 1, 0, 0, 0, 0, date.Location())
+		start = p.billingDayIn(previous.Year(), previous.Month(), date.Location())
+	}
+	return start
+}
+
+// BillingMonthEnd returns the last day of the billing month containing date
+func (p *Plan) BillingMonthEnd(date time.Time) time.Time {
+	start := p.BillingMonthStart(date)
+	next := time.Date(start.Year(), start.Month()+1, 1, 0, 0, 0, 0, date.Location())
+	return p.billingDayIn(next.Year(), next.Month(), date.Location()).AddDate(0, 0, -1)
+}
+
+// billingDayIn returns the billing day of the given month
+func (p *Plan) billingDayIn(year int, month time.Month, location *time.Location) time.Time {
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, location).Day()
+	return time.Date(year, month, min(p.BillingDay, lastDay), 0, 0, 0, 0, location)
+}
+
+// PlanValue represents the API-equivalent cost of one billing month compared with the plan price
+type PlanValue struct {
+	Start    time.Time // First day of the billing month
+	End      time.Time // First day of the next billing month
+	Days     int       // Days with usage
+	Cost     float64   // API-equivalent cost in USD
+	Price    float64   // Plan price in USD
+	Multiple float64   // Cost divided by price
+}
+
+// PlanValueByBillingMonth returns the value multiple of every billing month with usage, oldest first
+func (r *CostResponse) PlanValueByBillingMonth(plan Plan) []PlanValue {
+	index := make(map[time.Time]int)
+	values := []PlanValue{}
+	for _, day := range r.Daily {
+		date, err := time.Parse("2006-01-02", day.Date)
+		if err != nil {
+			continue
+		}
+		start := plan.BillingMonthStart(date)
+		i, ok := index[start]
+		if !ok {
+			i = len(values)
+			index[start] = i
+			next := time.Date(start.Year(), start.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			values = append(values, PlanValue{
+				Start: start,
+				End:   plan.billingDayIn(next.Year(), next.Month(), time.UTC),
+				Price: plan.MonthlyPrice,
+			})
+		}
+		values[i].Days++
+		values[i].Cost += day.TotalCost
+	}
+
+	for i := range values {
+		values[i].Multiple = values[i].Cost / values[i].Price
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Start.Before(values[j].Start)
+	})
+	return values
+}
+
+// CurrentPlanValue returns the value multiple of the billing month containing now
+func (r *CostResponse) CurrentPlanValue(plan Plan, now time.Time) PlanValue {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	start := plan.BillingMonthStart(today)
+	for _, value := range r.PlanValueByBillingMonth(plan) {
+		if value.Start.Equal(start) {
+			return value
+		}
+	}
+	next := time.Date(start.Year(), start.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	return PlanValue{Start: start, End: plan.billingDayIn(next.Year(), next.Month(), time.UTC), Price: plan.MonthlyPrice}
+}
@@ -0,0 +1,206 @@
+package entities
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func parseDate(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse(dateLayout, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+func TestParsePlan(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Plan
+		name  string
+	}{
+		{"pro", Plan{Name: "pro", MonthlyPrice: 20, BillingDay: 1}, "Pro"},
+		{"Max-5x", Plan{Name: "max-5x", MonthlyPrice: 100, BillingDay: 1}, "Max 5x"},
+		{"max-20x", Plan{Name: "max-20x", MonthlyPrice: 200, BillingDay: 1}, "Max 20x"},
+		// A number is a custom monthly price in USD
+		{"50", Plan{Name: "custom", MonthlyPrice: 50, BillingDay: 1}, "$50.00/month"},
+		{"12.5", Plan{Name: "custom", MonthlyPrice: 12.5, BillingDay: 1}, "$12.50/month"},
+	}
+	for _, tt := range tests {
+		got, err := ParsePlan(tt.value)
+		if err != nil {
+			t.Errorf("%q: %v", tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%q: got %+v, want %+v", tt.value, got, tt.want)
+		}
+		if name := got.DisplayName(); name != tt.name {
+			t.Errorf("%q: got display name %q, want %q", tt.value, name, tt.name)
+		}
+	}
+}
+
+func TestParsePlanErrors(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"team", `unknown plan "team"`},
+		{"max", `unknown plan "max"`},
+		{"0", "plan price must be a positive number, got 0"},
+		{"-20", "plan price must be a positive number, got -20"},
+		{"NaN", "plan price must be a positive number, got NaN"},
+		{"Inf", "plan price must be a positive number, got +Inf"},
+	}
+	for _, tt := range tests {
+		_, err := ParsePlan(tt.value)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%q: got error %v, want one containing %q", tt.value, err, tt.want)
+		}
+	}
+}
+
+func TestPlanValidate(t *testing.T) {
+	// A plan file may set its own price for a known plan and a billing day
+	plan := Plan{Name: "Pro", MonthlyPrice: 18, BillingDay: 15}
+	if err := plan.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if plan != (Plan{Name: "pro", MonthlyPrice: 18, BillingDay: 15}) {
+		t.Errorf("got %+v, want pro at 18 billed on the 15th", plan)
+	}
+
+	// An unnamed plan is a custom one, which needs a price
+	unnamed := Plan{MonthlyPrice: 30}
+	if err := unnamed.Validate(); err != nil || unnamed.Name != "custom" {
+		t.Errorf("got %+v and error %v, want a custom plan", unnamed, err)
+	}
+
+	tests := []struct {
+		name string
+		plan Plan
+		want string
+	}{
+		{"custom without price", Plan{Name: "custom"}, "plan price must be a positive number, got 0"},
+		{"negative price", Plan{Name: "pro", MonthlyPrice: -1}, "plan price must be a positive number, got -1"},
+		{"NaN price", Plan{Name: "custom", MonthlyPrice: math.NaN()}, "got NaN"},
+		{"billing day 32", Plan{Name: "pro", BillingDay: 32}, "billing day must be between 1 and 31, got 32"},
+		{"negative billing day", Plan{Name: "pro", BillingDay: -1}, "billing day must be between 1 and 31, got -1"},
+	}
+	for _, tt := range tests {
+		plan := tt.plan
+		err := plan.Validate()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: got error %v, want one containing %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestBillingMonth(t *testing.T) {
+	tests := []struct {
+		billingDay  int
+		date        string
+		start, last string
+	}{
+		{1, "2026-10-15", "2026-10-01", "2026-10-31"},
+		{1, "2026-10-01", "2026-10-01", "2026-10-31"},
+		{15, "2026-10-15", "2026-10-15", "2026-11-14"},
+		// Before the billing day the month started in the previous calendar month
+		{15, "2026-10-14", "2026-09-15", "2026-10-14"},
+		{20, "2026-01-15", "2025-12-20", "2026-01-19"},
+		// Day 31 falls on the last day of short months
+		{31, "2026-02-27", "2026-01-31", "2026-02-27"},
+		{31, "2026-02-28", "2026-02-28", "2026-03-30"},
+		{31, "2026-03-01", "2026-02-28", "2026-03-30"},
+		{31, "2026-03-31", "2026-03-31", "2026-04-29"},
+		{31, "2028-02-29", "2028-02-29", "2028-03-30"},
+		// Day 30 in a 30-day month, and in February
+		{30, "2026-04-30", "2026-04-30", "2026-05-29"},
+		{30, "2026-04-29", "2026-03-30", "2026-04-29"},
+		{30, "2026-05-01", "2026-04-30", "2026-05-29"},
+		{30, "2026-02-28", "2026-02-28", "2026-03-29"},
+	}
+	for _, tt := range tests {
+		plan := Plan{Name: "pro", MonthlyPrice: 20, BillingDay: tt.billingDay}
+		day := parseDate(t, tt.date)
+		if got := plan.BillingMonthStart(day).Format(dateLayout); got != tt.start {
+			t.Errorf("day %d, %s: got start %s, want %s", tt.billingDay, tt.date, got, tt.start)
+		}
+		if got := plan.BillingMonthEnd(day).Format(dateLayout); got != tt.last {
+			t.Errorf("day %d, %s: got end %s, want %s", tt.billingDay, tt.date, got, tt.last)
+		}
+	}
+}
+
+func TestPlanValueByBillingMonth(t *testing.T) {
+	plan := Plan{Name: "pro", MonthlyPrice: 20, BillingDay: 31}
+	// Out of order, with a date that does not parse
+	costData := dailyCosts(t,
+		"2026-03-31", 40.0,
+		"2026-01-31", 10.0,
+		"2026-02-28", 30.0,
+		"2026-02-27", 10.0,
+		"2026-03-30", 5.0,
+		"not-a-date", 100.0,
+	)
+
+	tests := []struct {
+		start, end string
+		days       int
+		cost       float64
+		multiple   float64
+	}{
+		{"2026-01-31", "2026-02-28", 2, 20, 1},
+		{"2026-02-28", "2026-03-31", 2, 35, 1.75},
+		{"2026-03-31", "2026-04-30", 1, 40, 2},
+	}
+	values := costData.PlanValueByBillingMonth(plan)
+	if len(values) != len(tests) {
+		t.Fatalf("got %d billing months, want %d", len(values), len(tests))
+	}
+	for i, tt := range tests {
+		value := values[i]
+		if value.Start.Format(dateLayout) != tt.start || value.End.Format(dateLayout) != tt.end {
+			t.Errorf("month %d: got %s - %s, want %s - %s", i,
+				value.Start.Format(dateLayout), value.End.Format(dateLayout), tt.start, tt.end)
+		}
+		if value.Days != tt.days {
+			t.Errorf("month %s: got %d days, want %d", tt.start, value.Days, tt.days)
+		}
+		assertCost(t, tt.start+" cost", value.Cost, tt.cost)
+		assertCost(t, tt.start+" price", value.Price, 20)
+		assertCost(t, tt.start+" multiple", value.Multiple, tt.multiple)
+	}
+}
+
+func TestCurrentPlanValue(t *testing.T) {
+	plan := Plan{Name: "max-5x", MonthlyPrice: 100, BillingDay: 31}
+	costData := dailyCosts(t, "2026-03-30", 5.0, "2026-03-31", 40.0, "2026-04-20", 10.0)
+
+	tests := []struct {
+		now        time.Time
+		start, end string
+		cost       float64
+		multiple   float64
+	}{
+		{time.Date(2026, 3, 30, 12, 0, 0, 0, time.UTC), "2026-02-28", "2026-03-31", 5, 0.05},
+		{time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), "2026-03-31", "2026-04-30", 50, 0.5},
+		{time.Date(2026, 4, 29, 23, 59, 0, 0, time.UTC), "2026-03-31", "2026-04-30", 50, 0.5},
+		// A billing month without usage
+		{time.Date(2026, 5, 15, 12, 0, 0, 0, time.UTC), "2026-04-30", "2026-05-31", 0, 0},
+	}
+	for _, tt := range tests {
+		value := costData.CurrentPlanValue(plan, tt.now)
+		if value.Start.Format(dateLayout) != tt.start || value.End.Format(dateLayout) != tt.end {
+			t.Errorf("%s: got %s - %s, want %s - %s", tt.now.Format(dateLayout),
+				value.Start.Format(dateLayout), value.End.Format(dateLayout), tt.start, tt.end)
+		}
+		assertCost(t, tt.now.Format(dateLayout)+" cost", value.Cost, tt.cost)
+		assertCost(t, tt.now.Format(dateLayout)+" price", value.Price, 100)
+		assertCost(t, tt.now.Format(dateLayout)+" multiple", value.Multiple, tt.multiple)
+	}
+}
@@ -2,10 +2,21 @@ package entities
 
 import "time"
 
+// Palette identifies the set of colors the animation cycles through
+type Palette string
+
+// Palettes the animation can use
+const (
+	PaletteRainbow  Palette = "rainbow"  // The usual rainbow
+	PaletteWarning  Palette = "warning"  // Ambers, for a budget nearing its limit
+	PaletteCritical Palette = "critical" // Reds, for a budget at or over its limit
+)
+
 // RainbowAnimation represents the state of rainbow color animation
 type RainbowAnimation struct {
 	Offset   int
 	Interval time.Duration
+	Palette  Palette
 }
 
 // NewRainbowAnimation creates a new RainbowAnimation
@@ -13,6 +24,7 @@ func NewRainbowAnimation(interval time.Duration) *RainbowAnimation {
 	return &RainbowAnimation{
 		Offset:   0,
 		Interval: interval,
+		Palette:  PaletteRainbow,
 	}
 }
 
@@ -30,3 +42,13 @@ func (r *RainbowAnimation) GetOffset() int {
 func (r *RainbowAnimation) GetInterval() time.Duration {
 	return r.Interval
 }
+
+// SetInterval changes how often the animation advances
+func (r *RainbowAnimation) SetInterval(interval time.Duration) {
+	r.Interval = interval
+}
+
+// SetPalette changes the colors the animation cycles through
+func (r *RainbowAnimation) SetPalette(palette Palette) {
+	r.Palette = palette
+}
@@ -1,5 +1,7 @@
 package entities
 
+import "unicode/utf8"
+
 // Text represents a text to be displayed as ASCII art
 type Text struct {
 	Content string
@@ -17,7 +19,7 @@ func (t *Text) IsEmpty() bool {
 	return t.Content == ""
 }
 
-// Length returns the length of the text content
+// Length returns the number of characters in the text content
 func (t *Text) Length() int {
-	return len(t.Content)
+	return utf8.RuneCountInString(t.Content)
 }
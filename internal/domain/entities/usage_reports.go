@@ -0,0 +1,182 @@
+package entities
+
+import (
+	"math"
+	"time"
+)
+
+// MonthlyResponse represents the response from ccusage monthly report
+type MonthlyResponse struct {
+	Monthly []MonthlyUsage `json:"monthly"`
+	Totals  Totals         `json:"totals"`
+}
+
+// MonthlyUsage represents monthly usage statistics
+type MonthlyUsage struct {
+	Month               string           `json:"month"` // YYYY-MM
+	InputTokens         int              `json:"inputTokens"`
+	OutputTokens        int              `json:"outputTokens"`
+	CacheCreationTokens int              `json:"cacheCreationTokens"`
+	CacheReadTokens     int              `json:"cacheReadTokens"`
+	TotalTokens         int              `json:"totalTokens"`
+	TotalCost           float64          `json:"totalCost"`
+	ModelsUsed          []string         `json:"modelsUsed"`
+	ModelBreakdowns     []ModelBreakdown `json:"modelBreakdowns"`
+}
+
+// FindMonth returns the usage of the given month (YYYY-MM), or nil if there is none
+func (r *MonthlyResponse) FindMonth(month string) *MonthlyUsage {
+	for i := range r.Monthly {
+		if r.Monthly[i].Month == month {
+			return &r.Monthly[i]
+		}
+	}
+	return nil
+}
+
+// FilterByDateRange returns a copy containing only the months that overlap the range, with totals
+// recomputed. Months are kept whole, a monthly report has no days to cut them at.
+func (r *MonthlyResponse) FilterByDateRange(dateRange DateRange) *MonthlyResponse {
+	filtered := &MonthlyResponse{Monthly: []MonthlyUsage{}}
+	for _, month := range r.Monthly {
+		// A month overlaps the range unless it ends before the start or starts after the end
+		if (dateRange.Since != "" && month.Month+"-31" < dateRange.Since) || (dateRange.Until != "" && month.Month+"-01" > dateRange.Until) {
+			continue
+		}
+		filtered.Monthly = append(filtered.Monthly, month)
+		filtered.Totals.InputTokens += month.InputTokens
+		filtered.Totals.OutputTokens += month.OutputTokens
+		filtered.Totals.CacheCreationTokens += month.CacheCreationTokens
+		filtered.Totals.CacheReadTokens += month.CacheReadTokens
+		filtered.Totals.TotalTokens += month.TotalTokens
+		filtered.Totals.TotalCost += month.TotalCost
+	}
+	return filtered
+}
+
+// SessionResponse represents the response from ccusage session report
+type SessionResponse struct {
+	Sessions []SessionUsage `json:"sessions"`
+	Totals   Totals         `json:"totals"`
+}
+
+// SessionUsage represents usage statistics of a single Claude Code session
+type SessionUsage struct {
+	SessionID           string           `json:"sessionId"`
+	ProjectPath         string           `json:"projectPath"`
+	LastActivity        string           `json:"lastActivity"` // YYYY-MM-DD
+	InputTokens         int              `json:"inputTokens"`
+	OutputTokens        int              `json:"outputTokens"`
+	CacheCreationTokens int              `json:"cacheCreationTokens"`
+	CacheReadTokens     int              `json:"cacheReadTokens"`
+	TotalTokens         int              `json:"totalTokens"`
+	TotalCost           float64          `json:"totalCost"`
+	ModelsUsed          []string         `json:"modelsUsed"`
+	ModelBreakdowns     []ModelBreakdown `json:"modelBreakdowns"`
+}
+
+// LatestSession returns the most recently active session, or nil if there is none
+func (r *SessionResponse) LatestSession() *SessionUsage {
+	var latest *SessionUsage
+	for i := range r.Sessions {
+		if latest == nil || r.Sessions[i].LastActivity > latest.LastActivity {
+			latest = &r.Sessions[i]
+		}
+	}
+	return latest
+}
+
+// BlocksResponse represents the response from ccusage blocks report
+type BlocksResponse struct {
+	Blocks []Block `json:"blocks"`
+}
+
+// Block represents a 5-hour billing block
+type Block struct {
+	ID            string           `json:"id"`
+	StartTime     time.Time        `json:"startTime"`
+	EndTime       time.Time        `json:"endTime"`
+	ActualEndTime *time.Time       `json:"actualEndTime"`
+	IsActive      bool             `json:"isActive"`
+	IsGap         bool             `json:"isGap"`
+	Entries       int              `json:"entries"`
+	TokenCounts   BlockTokenCounts `json:"tokenCounts"`
+	TotalTokens   int              `json:"totalTokens"`
+	CostUSD       float64          `json:"costUSD"`
+	Models        []string         `json:"models"`
+	BurnRate      *BurnRate        `json:"burnRate"`
+	Projection    *BlockProjection `json:"projection"`
+}
+
+// BlockTokenCounts represents the token counts of a billing block
+type BlockTokenCounts struct {
+	InputTokens              int `json:"inputTokens"`
+	OutputTokens             int `json:"outputTokens"`
+	CacheCreationInputTokens int `json:"cacheCreationInputTokens"`
+	CacheReadInputTokens     int `json:"cacheReadInputTokens"`
+}
+
+// BurnRate represents how fast an active block consumes tokens and money
+type BurnRate struct {
+	TokensPerMinute float64 `json:"tokensPerMinute"`
+	CostPerHour     float64 `json:"costPerHour"`
+}
+
+// BlockProjection represents the projected usage of an active block at its end
+type BlockProjection struct {
+	TotalTokens      int     `json:"totalTokens"`
+	TotalCost        float64 `json:"totalCost"`
+	RemainingMinutes int     `json:"remainingMinutes"`
+}
+
+// ActiveBlock returns the currently active block, or nil if there is none
+func (r *BlocksResponse) ActiveBlock() *Block {
+	for i := range r.Blocks {
+		if r.Blocks[i].IsActive && !r.Blocks[i].IsGap {
+			return &r.Blocks[i]
+		}
+	}
+	return nil
+}
+
+// Remaining returns the time left until the block resets, zero once it has ended
+func (b *Block) Remaining(now time.Time) time.Duration {
+	return max(0, b.EndTime.Sub(now))
+}
+
+// activeMinutes returns the minutes from the block's start to its last activity, or to now if
+// the last activity is unknown
+func (b *Block) activeMinutes(now time.Time) float64 {
+	last := now
+	if b.ActualEndTime != nil {
+		last = *b.ActualEndTime
+	}
+	return last.Sub(b.StartTime).Minutes()
+}
+
+// TokensPerMinute returns the block's token burn rate, from the reported burn rate when there is one
+func (b *Block) TokensPerMinute(now time.Time) float64 {
+	if b.BurnRate != nil {
+		return b.BurnRate.TokensPerMinute
+	}
+	if minutes := b.activeMinutes(now); minutes > 0 {
+		return float64(b.TotalTokens) / minutes
+	}
+	return 0
+}
+
+// CostPerHour returns the block's cost burn rate, from the reported burn rate when there is one
+func (b *Block) CostPerHour(now time.Time) float64 {
+	if b.BurnRate != nil {
+		return b.BurnRate.CostPerHour
+	}
+	if minutes := b.activeMinutes(now); minutes > 0 {
+		return b.CostUSD / minutes * 60
+	}
+	return 0
+}
+
+// ProjectedCost returns the block's cost at its end if spending continues at the current burn rate
+func (b *Block) ProjectedCost(now time.Time) float64 {
+	return b.CostUSD + b.CostPerHour(now)*math.Max(0, b.Remaining(now).Hours())
+}
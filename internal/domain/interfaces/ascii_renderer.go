@@ -1,22 +1,83 @@
 package interfaces
 
-import "ccusage-rainbow/internal/domain/entities"
+import (
+	"ccusage-rainbow/internal/domain/entities"
+	"fmt"
+	"math"
+)
+
+// fontBaseRows is the height of a character at font size 1
+const fontBaseRows = 5
+
+// FontSize represents the scale of ASCII art characters relative to the small size. The
+// hand-drawn sizes are drawn as they are; any other size is upsampled from the largest
+// hand-drawn size below it.
+type FontSize float64
+
+const (
+	FontSizeSmall  FontSize = 1   // 5x7
+	FontSizeMedium FontSize = 1.4 // 7x9
+	FontSizeLarge  FontSize = 2   // 10x14
+)
+
+// FontSizeForRows returns the font size whose characters are the given number of rows tall
+func FontSizeForRows(rows int) FontSize {
+	return FontSize(rows) / fontBaseRows
+}
+
+// Rows returns the number of rows a character is tall at this size
+func (s FontSize) Rows() int {
+	return int(math.Round(float64(s) * fontBaseRows))
+}
 
-// FontSize represents the size of ASCII art characters
-type FontSize int
+// RenderMode represents how the pixels of the built-in and bitmap glyphs are packed into terminal cells
+type RenderMode string
 
+// Render modes, from coarsest to finest
 const (
-	FontSizeSmall  FontSize = 0 // 5x7
-	FontSizeMedium FontSize = 1 // 7x9
-	FontSizeLarge  FontSize = 2 // 10x13
+	RenderModeBlock    RenderMode = "block"    // One pixel per cell, drawn with █
+	RenderModeHalf     RenderMode = "half"     // 1x2 pixels per cell, drawn with ▀, ▄ and █
+	RenderModeQuadrant RenderMode = "quadrant" // 2x2 pixels per cell, drawn with quadrant blocks such as ▚
+	RenderModeBraille  RenderMode = "braille"  // 2x4 pixels per cell, drawn with braille dots
 )
 
+// ParseRenderMode parses a render mode name
+func ParseRenderMode(value string) (RenderMode, error) {
+	switch mode := RenderMode(value); mode {
+	case RenderModeBlock, RenderModeHalf, RenderModeQuadrant, RenderModeBraille:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown render mode %q (expected block, half, quadrant or braille)", value)
+	}
+}
+
+// CellPixels returns how many pixels wide and tall a terminal cell is in this mode
+func (m RenderMode) CellPixels() (width, height int) {
+	switch m {
+	case RenderModeHalf:
+		return 1, 2
+	case RenderModeQuadrant:
+		return 2, 2
+	case RenderModeBraille:
+		return 2, 4
+	default:
+		return 1, 1
+	}
+}
+
+// MinFontSize returns the smallest font size at which the small glyphs still get all their pixels
+func (m RenderMode) MinFontSize() FontSize {
+	_, height := m.CellPixels()
+	return FontSizeForRows((FontSizeSmall.Rows() + height - 1) / height)
+}
+
 // ASCIIRenderer defines the interface for rendering text as ASCII art
 type ASCIIRenderer interface {
 	// RenderPlain renders text as plain ASCII art without colors
 	RenderPlain(text *entities.Text) (string, error)
 
-	// RenderPlainWithSize renders text with specified font size
+	// RenderPlainWithSize renders text with specified font size, scaling the built-in glyphs to sizes
+	// between and above the hand-drawn ones and bitmap fonts from their own height
 	RenderPlainWithSize(text *entities.Text, size FontSize) (string, error)
 
 	// GetDisplayWidth calculates the actual display width of rendered text
@@ -24,4 +85,14 @@ type ASCIIRenderer interface {
 
 	// GetDisplayWidthWithSize calculates display width for specific font size
 	GetDisplayWidthWithSize(text *entities.Text, size FontSize) (int, error)
+
+	// SelectRenderMode chooses how the pixels of the built-in and bitmap glyphs are packed into cells
+	SelectRenderMode(mode RenderMode)
+
+	// SelectFont switches to a font file given by path, or by name from the user font directory;
+	// an empty name or "builtin" switches back to the built-in font
+	SelectFont(name string) error
+
+	// ListFonts returns the names of the fonts that can be selected
+	ListFonts() ([]string, error)
 }
@@ -0,0 +1,10 @@
+package interfaces
+
+import "ccusage-rainbow/internal/domain/entities"
+
+// BudgetProvider defines the interface for loading the spending budget
+type BudgetProvider interface {
+	// LoadBudget reads the budget from a config file, or from the default location when path is empty.
+	// It returns nil without an error when no path is given and no default file exists.
+	LoadBudget(path string) (*entities.Budget, error)
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import "ccusage-rainbow/internal/domain/entities"
+
+// CostCache defines the interface for persisting the last good cost data
+type CostCache interface {
+	// Load returns the cached data for key, or nil if there is none
+	Load(key string) (*entities.CachedCostResponse, error)
+
+	// Save stores data for key
+	Save(key string, cached *entities.CachedCostResponse) error
+}
@@ -1,9 +1,58 @@
 package interfaces
 
-import "ccusage-rainbow/internal/domain/entities"
+import (
+	"ccusage-rainbow/internal/domain/entities"
+	"context"
+	"time"
+)
+
+// Cost source names accepted by CostSourceSelector
+const (
+	CostSourceAuto   = "auto"   // ccusage via npx, falling back to native when npx is missing
+	CostSourceNpx    = "npx"    // ccusage via npx
+	CostSourceNative = "native" // Claude Code JSONL logs read directly
+	CostSourceInput  = "input"  // Saved ccusage JSON reports, selected by CostSourceOptions.InputPaths
+)
 
 // CostService defines the interface for fetching cost data
 type CostService interface {
-	// FetchCostData fetches cost data from ccusage command
-	FetchCostData() (*entities.CostResponse, error)
+	// FetchCostData fetches the daily report within dateRange, all usage when it is empty,
+	// aborting when ctx is cancelled
+	FetchCostData(ctx context.Context, dateRange entities.DateRange) (*entities.CostResponse, error)
+
+	// FetchCostDataBySource fetches the daily report within dateRange separately for every
+	// configured source (input file or Claude data directory), aborting when ctx is cancelled
+	FetchCostDataBySource(ctx context.Context, dateRange entities.DateRange) ([]entities.SourceCostResponse, error)
+
+	// FetchMonthlyData fetches the monthly report, aborting when ctx is cancelled
+	FetchMonthlyData(ctx context.Context) (*entities.MonthlyResponse, error)
+
+	// FetchSessionData fetches the per-session report, aborting when ctx is cancelled
+	FetchSessionData(ctx context.Context) (*entities.SessionResponse, error)
+
+	// FetchBlocksData fetches the 5-hour billing block report, aborting when ctx is cancelled
+	FetchBlocksData(ctx context.Context) (*entities.BlocksResponse, error)
+}
+
+// ConfigurableCostService defines a cost source that takes its settings from the cost source options
+type ConfigurableCostService interface {
+	CostService
+
+	// Configure applies the options that concern this source, such as its date range
+	Configure(options CostSourceOptions)
+}
+
+// CostSourceOptions represents the options used to choose where cost data comes from
+type CostSourceOptions struct {
+	Source     string
+	Timeout    time.Duration      // Maximum duration of a single fetch, 0 for no limit
+	DateRange  entities.DateRange // Limits monthly, session and block reports; daily reports take their range per fetch
+	InputPaths []string           // Read and merge ccusage JSON reports from these files ("-" for stdin) instead of Source
+	ClaudeDirs []string           // Claude config directories the native source reads, instead of the defaults
+}
+
+// CostSourceSelector defines the interface for choosing the active cost data source
+type CostSourceSelector interface {
+	// SelectSource configures which cost source subsequent fetches use
+	SelectSource(options CostSourceOptions) error
 }
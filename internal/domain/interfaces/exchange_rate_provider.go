@@ -0,0 +1,9 @@
+package interfaces
+
+import "ccusage-rainbow/internal/domain/entities"
+
+// ExchangeRateProvider defines the interface for loading exchange rates
+type ExchangeRateProvider interface {
+	// LoadRates reads exchange rates from a rate file, or from the default location when path is empty
+	LoadRates(path string) (*entities.ExchangeRates, error)
+}
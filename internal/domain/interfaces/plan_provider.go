@@ -0,0 +1,10 @@
+package interfaces
+
+import "ccusage-rainbow/internal/domain/entities"
+
+// PlanProvider defines the interface for loading the subscription plan
+type PlanProvider interface {
+	// LoadPlan reads the plan from a config file, or from the default location when path is empty.
+	// It returns nil without an error when no path is given and no default file exists.
+	LoadPlan(path string) (*entities.Plan, error)
+}
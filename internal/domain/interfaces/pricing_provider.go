@@ -0,0 +1,12 @@
+package interfaces
+
+import "ccusage-rainbow/internal/domain/entities"
+
+// PricingProvider defines the interface for looking up model prices
+type PricingProvider interface {
+	// GetModelPricing returns the pricing of a model, matching dated and provider-prefixed names
+	GetModelPricing(modelName string) (*entities.ModelPricing, error)
+
+	// LoadOverrides merges prices from a LiteLLM-style JSON file over the built-in table
+	LoadOverrides(path string) error
+}
@@ -2,11 +2,17 @@ package di
 
 import (
 	"ccusage-rainbow/internal/infrastructure/ascii"
+	"ccusage-rainbow/internal/infrastructure/cache"
 	"ccusage-rainbow/internal/infrastructure/color"
+	"ccusage-rainbow/internal/infrastructure/config"
 	costInfra "ccusage-rainbow/internal/infrastructure/cost"
+	currencyInfra "ccusage-rainbow/internal/infrastructure/currency"
+	pricingInfra "ccusage-rainbow/internal/infrastructure/pricing"
 	"ccusage-rainbow/internal/interfaces/cli"
 	costUseCase "ccusage-rainbow/internal/usecase/cost"
+	pricingUseCase "ccusage-rainbow/internal/usecase/pricing"
 	"ccusage-rainbow/internal/usecase/rainbow"
+	"os"
 )
 
 // Container holds all dependencies
@@ -19,14 +25,24 @@ func NewContainer() *Container {
 	// Infrastructure layer
 	asciiRenderer := ascii.NewRenderer()
 	colorAnimator := color.NewAnimator()
-	costService := costInfra.NewService()
+	pricingTable := pricingInfra.NewTable()
+	costSelector := costInfra.NewSelector(
+		costInfra.NewService(),
+		costInfra.NewNativeService(pricingTable),
+		costInfra.NewInputService(os.Stdin),
+	)
+	costCache := cache.NewFileCache()
+	rateFile := currencyInfra.NewRateFile()
+	budgetFile := config.NewBudgetFile()
+	planFile := config.NewPlanFile()
 
 	// Use case layer
 	rainbowUseCase := rainbow.NewRainbowTextUseCase(asciiRenderer, colorAnimator)
-	costDisplayUseCase := costUseCase.NewCostDisplayUseCase(costService)
+	costDisplayUseCase := costUseCase.NewCostDisplayUseCase(costSelector, costCache, rateFile, budgetFile, planFile, pricingTable)
+	costCalculatorUseCase := pricingUseCase.NewCostCalculatorUseCase(pricingTable)
 
 	// Interface adapters layer
-	cliController := cli.NewController(rainbowUseCase, costDisplayUseCase)
+	cliController := cli.NewController(rainbowUseCase, costDisplayUseCase, costCalculatorUseCase, costSelector)
 
 	return &Container{
 		cliController: cliController,
@@ -0,0 +1,366 @@
+package ascii
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// bitmapPixel is the block cell a set pixel of a bitmap font becomes
+const bitmapPixel = "█"
+
+// maxBitmapSize is the most pixels a bitmap glyph may be wide or tall; larger sizes are corrupt
+// files rather than fonts meant for a terminal
+const maxBitmapSize = 1024
+
+var (
+	psf1Magic = []byte{0x36, 0x04}
+	psf2Magic = []byte{0x72, 0xb5, 0x4a, 0x86}
+	gzipMagic = []byte{0x1f, 0x8b}
+)
+
+// BitmapFont represents a bitmap font, such as a Linux console font, drawn with a block per pixel
+type BitmapFont struct {
+	height int
+	glyphs map[rune][]string // Rows of block cells and spaces, as wide as the glyph advances
+}
+
+// LoadBitmapFont reads a BDF or PSF font file, gzip-compressed or not
+func LoadBitmapFont(path string) (*BitmapFont, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(data, gzipMagic) {
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid bitmap font %s: %w", path, err)
+		}
+		data, err = io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bitmap font %s: %w", path, err)
+		}
+	}
+
+	var font *BitmapFont
+	switch {
+	case bytes.HasPrefix(data, psf1Magic):
+		font, err = ParsePSF1Font(data)
+	case bytes.HasPrefix(data, psf2Magic):
+		font, err = ParsePSF2Font(data)
+	case bytes.HasPrefix(data, []byte("STARTFONT")):
+		font, err = ParseBDFFont(bytes.NewReader(data))
+	default:
+		err = fmt.Errorf("neither a BDF nor a PSF font")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid bitmap font %s: %w", path, err)
+	}
+	return font, nil
+}
+
+// ParseBDFFont parses a font in the Glyph Bitmap Distribution Format. Glyphs are placed in the
+// font's bounding box by their own bounding box, so every glyph shares the baseline.
+func ParseBDFFont(reader io.Reader) (*BitmapFont, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	font := &BitmapFont{glyphs: make(map[rune][]string)}
+	var boxWidth, boxX, boxY int
+	var code int
+	var advance, width, height, x, y int
+	var bitmap [][]byte
+	inBitmap := false
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if inBitmap && fields[0] != "ENDCHAR" {
+			row, err := hex.DecodeString(fields[0])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid bitmap row %q", line, fields[0])
+			}
+			bitmap = append(bitmap, row)
+			continue
+		}
+
+		numbers, err := atoiAll(fields[1:])
+		switch fields[0] {
+		case "FONTBOUNDINGBOX":
+			if err != nil || len(numbers) < 4 || !validBitmapSize(numbers[0]) || !validBitmapSize(numbers[1]) || numbers[1] < 1 {
+				return nil, fmt.Errorf("line %d: invalid FONTBOUNDINGBOX", line)
+			}
+			boxWidth, font.height, boxX, boxY = numbers[0], numbers[1], numbers[2], numbers[3]
+		case "STARTCHAR":
+			code, advance = -1, boxWidth
+			width, height, x, y = boxWidth, font.height, boxX, boxY
+			bitmap = nil
+		case "ENCODING":
+			// ENCODING -1 n gives a glyph outside the standard encoding the code n
+			if err != nil || len(numbers) == 0 {
+				return nil, fmt.Errorf("line %d: invalid ENCODING", line)
+			}
+			code = numbers[0]
+			if code < 0 && len(numbers) > 1 {
+				code = numbers[1]
+			}
+		case "DWIDTH":
+			if err == nil && len(numbers) > 0 {
+				if !validBitmapSize(numbers[0]) {
+					return nil, fmt.Errorf("line %d: invalid DWIDTH", line)
+				}
+				advance = numbers[0]
+			}
+		case "BBX":
+			if err != nil || len(numbers) < 4 || !validBitmapSize(numbers[0]) || !validBitmapSize(numbers[1]) {
+				return nil, fmt.Errorf("line %d: invalid BBX", line)
+			}
+			width, height, x, y = numbers[0], numbers[1], numbers[2], numbers[3]
+		case "BITMAP":
+			if font.height == 0 {
+				return nil, fmt.Errorf("line %d: BITMAP before FONTBOUNDINGBOX", line)
+			}
+			inBitmap = true
+		case "ENDCHAR":
+			inBitmap = false
+			if code < 0 {
+				continue
+			}
+			// The glyph is as wide as it advances, or as far as its offset box reaches
+			glyphWidth := max(advance, x-boxX+width)
+			if glyphWidth > maxBitmapSize {
+				return nil, fmt.Errorf("line %d: glyph %d is %d pixels wide", line, code, glyphWidth)
+			}
+			// The top row of the font box is at y = boxY + height - 1, each glyph row one below the other
+			top := boxY + font.height - 1
+			cells := blankCells(font.height, glyphWidth)
+			for row, bits := range bitmap {
+				cellRow := top - (y + height - 1 - row)
+				if cellRow < 0 || cellRow >= font.height {
+					continue
+				}
+				for column := 0; column < width; column++ {
+					cellColumn := x - boxX + column
+					if cellColumn >= 0 && bitSet(bits, column) {
+						cells[cellRow][cellColumn] = true
+					}
+				}
+			}
+			font.glyphs[rune(code)] = drawCells(cells)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(font.glyphs) == 0 {
+		return nil, fmt.Errorf("no glyphs")
+	}
+	return font, nil
+}
+
+// ParsePSF1Font parses a PC Screen Font version 1: 256 or 512 glyphs 8 pixels wide, optionally
+// followed by a table of the Unicode code points each glyph draws
+func ParsePSF1Font(data []byte) (*BitmapFont, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("truncated header")
+	}
+	mode, height := data[2], int(data[3])
+	count := 256
+	if mode&0x01 != 0 {
+		count = 512
+	}
+	if height < 1 || len(data) < 4+count*height {
+		return nil, fmt.Errorf("truncated glyphs")
+	}
+
+	glyphs := make([][]byte, count)
+	for i := range glyphs {
+		glyphs[i] = data[4+i*height : 4+(i+1)*height]
+	}
+
+	// Each glyph's code points are 16-bit values ending in 0xFFFF; 0xFFFE starts combining sequences
+	var codes [][]rune
+	if mode&0x06 != 0 {
+		table := data[4+count*height:]
+		codes = make([][]rune, count)
+		glyph := 0
+		inSequence := false
+		for i := 0; i+1 < len(table) && glyph < count; i += 2 {
+			switch value := binary.LittleEndian.Uint16(table[i:]); value {
+			case 0xFFFF:
+				glyph++
+				inSequence = false
+			case 0xFFFE:
+				inSequence = true
+			default:
+				if !inSequence {
+					codes[glyph] = append(codes[glyph], rune(value))
+				}
+			}
+		}
+	}
+	return newPSFFont(glyphs, codes, 8, height), nil
+}
+
+// ParsePSF2Font parses a PC Screen Font version 2, optionally with a table of the UTF-8 encoded
+// code points each glyph draws
+func ParsePSF2Font(data []byte) (*BitmapFont, error) {
+	if len(data) < 32 {
+		return nil, fmt.Errorf("truncated header")
+	}
+	header := make([]int, 7)
+	for i := range header {
+		header[i] = int(binary.LittleEndian.Uint32(data[4+4*i:]))
+	}
+	headerSize, flags, count, glyphSize, height, width := header[1], header[2], header[3], header[4], header[5], header[6]
+	rowSize := (width + 7) / 8
+	if !validBitmapSize(height) || !validBitmapSize(width) || height < 1 || width < 1 || glyphSize < height*rowSize || count < 1 {
+		return nil, fmt.Errorf("invalid glyph size %dx%d", width, height)
+	}
+	if headerSize < 32 || headerSize > len(data) || (len(data)-headerSize)/glyphSize < count {
+		return nil, fmt.Errorf("truncated glyphs")
+	}
+
+	glyphs := make([][]byte, count)
+	for i := range glyphs {
+		start := headerSize + i*glyphSize
+		glyphs[i] = data[start : start+height*rowSize]
+	}
+
+	// Each glyph's code points are UTF-8 strings ending in 0xFF; 0xFE starts combining sequences
+	var codes [][]rune
+	if flags&0x01 != 0 {
+		table := data[headerSize+count*glyphSize:]
+		codes = make([][]rune, count)
+		for glyph := 0; glyph < count && len(table) > 0; glyph++ {
+			end := bytes.IndexByte(table, 0xFF)
+			if end < 0 {
+				end = len(table)
+			}
+			entry := table[:end]
+			if sequence := bytes.IndexByte(entry, 0xFE); sequence >= 0 {
+				entry = entry[:sequence]
+			}
+			for len(entry) > 0 {
+				char, size := utf8.DecodeRune(entry)
+				if char != utf8.RuneError {
+					codes[glyph] = append(codes[glyph], char)
+				}
+				entry = entry[size:]
+			}
+			table = table[min(end+1, len(table)):]
+		}
+	}
+	return newPSFFont(glyphs, codes, width, height), nil
+}
+
+// newPSFFont creates a bitmap font from PSF glyph rows, mapping each glyph to its code points from
+// the Unicode table, or to its index when the font has no table
+func newPSFFont(glyphs [][]byte, codes [][]rune, width, height int) *BitmapFont {
+	rowSize := (width + 7) / 8
+	font := &BitmapFont{height: height, glyphs: make(map[rune][]string)}
+	for i, glyph := range glyphs {
+		cells := blankCells(height, width)
+		for row := range cells {
+			bits := glyph[row*rowSize : (row+1)*rowSize]
+			for column := range cells[row] {
+				cells[row][column] = bitSet(bits, column)
+			}
+		}
+		drawn := drawCells(cells)
+
+		if codes == nil {
+			font.glyphs[rune(i)] = drawn
+			continue
+		}
+		for _, code := range codes[i] {
+			font.glyphs[code] = drawn
+		}
+	}
+	return font
+}
+
+// atoiAll parses every field as an integer
+func atoiAll(fields []string) ([]int, error) {
+	numbers := make([]int, len(fields))
+	for i, field := range fields {
+		number, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, err
+		}
+		numbers[i] = number
+	}
+	return numbers, nil
+}
+
+// validBitmapSize reports whether a glyph width or height is a size a bitmap font can have
+func validBitmapSize(size int) bool {
+	return size >= 0 && size <= maxBitmapSize
+}
+
+// bitSet reports whether a pixel is set in a row of bits, most significant bit first
+func bitSet(bits []byte, column int) bool {
+	if column/8 >= len(bits) {
+		return false
+	}
+	return bits[column/8]&(0x80>>(column%8)) != 0
+}
+
+// blankCells returns a grid of unset pixels
+func blankCells(height, width int) [][]bool {
+	cells := make([][]bool, height)
+	for i := range cells {
+		cells[i] = make([]bool, width)
+	}
+	return cells
+}
+
+// drawCells turns a grid of pixels into rows of block cells and spaces
+func drawCells(cells [][]bool) []string {
+	rows := make([]string, len(cells))
+	for i, row := range cells {
+		var line strings.Builder
+		for _, set := range row {
+			if set {
+				line.WriteString(bitmapPixel)
+			} else {
+				line.WriteByte(' ')
+			}
+		}
+		rows[i] = line.String()
+	}
+	return rows
+}
+
+// Height returns the number of rows of every glyph
+func (f *BitmapFont) Height() int {
+	return f.height
+}
+
+// Render draws text with a block per set pixel, glyph after glyph. Characters the font lacks
+// fall back to the other letter case, or are left out.
+func (f *BitmapFont) Render(content []rune) []string {
+	lines := make([]string, f.height)
+	for _, char := range content {
+		glyph, ok := lookupGlyph(f.glyphs, char)
+		if !ok {
+			continue
+		}
+		for i := range lines {
+			lines[i] += glyph[i]
+		}
+	}
+	return lines
+}
@@ -0,0 +1,250 @@
+package ascii
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+// bdfHeader starts a BDF font with a 4x4 box whose bottom row is one below the baseline
+const bdfHeader = `STARTFONT 2.1
+FONT test
+SIZE 4 75 75
+FONTBOUNDINGBOX 4 4 0 -1
+CHARS 5
+`
+
+func assertBitmapGlyph(t *testing.T, font *BitmapFont, char rune, want ...string) {
+	t.Helper()
+	glyph, ok := font.glyphs[char]
+	if !ok {
+		t.Errorf("%q: missing glyph", char)
+		return
+	}
+	if strings.Join(glyph, "\n") != strings.Join(want, "\n") {
+		t.Errorf("%q: got glyph %q, want %q", char, glyph, want)
+	}
+}
+
+func TestParseBDFFont(t *testing.T) {
+	font, err := ParseBDFFont(strings.NewReader(bdfHeader + `STARTCHAR A
+ENCODING 65
+DWIDTH 4 0
+BBX 2 2 1 0
+BITMAP
+C0
+40
+ENDCHAR
+STARTCHAR g
+ENCODING 103
+DWIDTH 2 0
+BBX 1 2 0 -1
+BITMAP
+80
+80
+ENDCHAR
+STARTCHAR wide
+ENCODING -1 200
+DWIDTH 3 0
+BBX 3 1 2 2
+BITMAP
+A0
+ENDCHAR
+STARTCHAR box
+ENCODING 9633
+BITMAP
+F0
+90
+90
+F0
+ENDCHAR
+STARTCHAR unencoded
+ENCODING -1
+BITMAP
+F0
+ENDCHAR
+ENDFONT
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if font.Height() != 4 {
+		t.Errorf("got height %d, want 4", font.Height())
+	}
+	if len(font.glyphs) != 4 {
+		t.Errorf("got %d glyphs, want 4", len(font.glyphs))
+	}
+	// The box rows are 2, 1, 0 and -1 from the baseline: A sits on it, g reaches below it
+	assertBitmapGlyph(t, font, 'A', "    ", " ██ ", "  █ ", "    ")
+	assertBitmapGlyph(t, font, 'g', "  ", "  ", "█ ", "█ ")
+	// An offset box reaches past the advance; ENCODING -1 n gives the code n
+	assertBitmapGlyph(t, font, 200, "  █ █", "     ", "     ", "     ")
+	// Without DWIDTH and BBX the glyph fills the font box
+	assertBitmapGlyph(t, font, '□', "████", "█  █", "█  █", "████")
+}
+
+func TestParseBDFFontErrors(t *testing.T) {
+	glyph := func(lines ...string) string {
+		return bdfHeader + "STARTCHAR x\nENCODING 120\n" + strings.Join(lines, "\n") + "\nBITMAP\n80\nENDCHAR\n"
+	}
+	tests := []struct {
+		name   string
+		source string
+	}{
+		{"negative BBX width", glyph("BBX -1 1 0 0")},
+		{"negative BBX height", glyph("BBX 1 -1 0 0")},
+		{"huge BBX width", glyph("BBX 100000 1 0 0")},
+		{"short BBX", glyph("BBX 1 1")},
+		{"negative DWIDTH", glyph("DWIDTH -4 0")},
+		{"huge DWIDTH", glyph("DWIDTH 100000 0")},
+		{"offset past the size limit", glyph("BBX 1 1 100000 0")},
+		{"negative box width", strings.Replace(glyph(), "FONTBOUNDINGBOX 4 4", "FONTBOUNDINGBOX -4 4", 1)},
+		{"zero box height", strings.Replace(glyph(), "FONTBOUNDINGBOX 4 4", "FONTBOUNDINGBOX 4 0", 1)},
+		{"huge box height", strings.Replace(glyph(), "FONTBOUNDINGBOX 4 4", "FONTBOUNDINGBOX 4 100000", 1)},
+		{"invalid bitmap row", strings.Replace(glyph(), "\n80\n", "\nxyz\n", 1)},
+		{"bitmap before box", "STARTFONT 2.1\nSTARTCHAR x\nENCODING 120\nBITMAP\n80\nENDCHAR\n"},
+		{"no glyphs", bdfHeader + "ENDFONT\n"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseBDFFont(strings.NewReader(tt.source)); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+// psf1Font builds a PSF1 font of 256 glyphs 2 rows tall with glyph A drawn as a diagonal; a
+// table gives glyph A the code points of entry, any other glyph none
+func psf1Font(mode byte, entry ...uint16) []byte {
+	data := append([]byte{}, psf1Magic...)
+	data = append(data, mode, 2)
+	glyphs := make([]byte, 256*2)
+	glyphs['A'*2], glyphs['A'*2+1] = 0x80, 0x01
+	data = append(data, glyphs...)
+	if mode&0x06 == 0 {
+		return data
+	}
+	for glyph := range 256 {
+		if glyph == 'A' {
+			for _, value := range entry {
+				data = binary.LittleEndian.AppendUint16(data, value)
+			}
+		}
+		data = binary.LittleEndian.AppendUint16(data, 0xFFFF)
+	}
+	return data
+}
+
+func TestParsePSF1Font(t *testing.T) {
+	diagonal := []string{"█       ", "       █"}
+
+	// Without a table glyphs map to their index
+	font, err := ParsePSF1Font(psf1Font(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if font.Height() != 2 || len(font.glyphs) != 256 {
+		t.Errorf("got height %d and %d glyphs, want 2 and 256", font.Height(), len(font.glyphs))
+	}
+	assertBitmapGlyph(t, font, 'A', diagonal...)
+	assertBitmapGlyph(t, font, 'B', "        ", "        ")
+
+	// The table maps glyph A to A and Alpha; the combining sequence after 0xFFFE is left out
+	font, err = ParsePSF1Font(psf1Font(0x02, 'A', 'Α', 0xFFFE, 'A', 0x0301))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(font.glyphs) != 2 {
+		t.Errorf("got %d glyphs, want 2", len(font.glyphs))
+	}
+	assertBitmapGlyph(t, font, 'A', diagonal...)
+	assertBitmapGlyph(t, font, 'Α', diagonal...)
+	if _, ok := font.glyphs[0x0301]; ok {
+		t.Error("got a glyph for the combining accent, want none")
+	}
+
+	for name, data := range map[string][]byte{
+		"truncated header": psf1Magic,
+		"truncated glyphs": psf1Font(0)[:300],
+		"512 glyphs":       psf1Font(0x01),
+		"zero height":      append(append([]byte{}, psf1Magic...), 0, 0),
+	} {
+		if _, err := ParsePSF1Font(data); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// psf2Font builds a PSF2 font from the rows of its glyphs, header padding and a Unicode table
+func psf2Font(headerSize, flags, width, height int, glyphs [][]byte, table []byte) []byte {
+	var buffer bytes.Buffer
+	buffer.Write(psf2Magic)
+	glyphSize := 0
+	if len(glyphs) > 0 {
+		glyphSize = len(glyphs[0])
+	}
+	for _, value := range []int{0, headerSize, flags, len(glyphs), glyphSize, height, width} {
+		_ = binary.Write(&buffer, binary.LittleEndian, uint32(value))
+	}
+	for buffer.Len() < headerSize {
+		buffer.WriteByte(0)
+	}
+	for _, glyph := range glyphs {
+		buffer.Write(glyph)
+	}
+	buffer.Write(table)
+	return buffer.Bytes()
+}
+
+func TestParsePSF2Font(t *testing.T) {
+	// Glyphs 10 pixels wide take 2 bytes a row: a full top row and the last pixel below it
+	glyphs := [][]byte{{0xFF, 0xC0, 0x00, 0x40}, {0x00, 0x00, 0x00, 0x00}}
+	corner := []string{"██████████", "         █"}
+	blank := []string{"          ", "          "}
+
+	// Without a table glyphs map to their index; the header may be longer than 32 bytes
+	font, err := ParsePSF2Font(psf2Font(36, 0, 10, 2, glyphs, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if font.Height() != 2 || len(font.glyphs) != 2 {
+		t.Errorf("got height %d and %d glyphs, want 2 and 2", font.Height(), len(font.glyphs))
+	}
+	assertBitmapGlyph(t, font, 0, corner...)
+	assertBitmapGlyph(t, font, 1, blank...)
+
+	// The table maps glyph 0 to é and €, leaving out the sequence after 0xFE, and glyph 1 to x
+	table := append([]byte("é€\xfeé\xff"), []byte("x\xff")...)
+	font, err = ParsePSF2Font(psf2Font(32, 0x01, 10, 2, glyphs, table))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(font.glyphs) != 3 {
+		t.Errorf("got %d glyphs, want 3", len(font.glyphs))
+	}
+	assertBitmapGlyph(t, font, 'é', corner...)
+	assertBitmapGlyph(t, font, '€', corner...)
+	assertBitmapGlyph(t, font, 'x', blank...)
+	if _, ok := font.glyphs['e']; ok {
+		t.Error("got a glyph for e of the sequence, want none")
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"truncated header", psf2Magic},
+		{"zero width", psf2Font(32, 0, 0, 2, glyphs, nil)},
+		{"huge width", psf2Font(32, 0, 100000, 2, glyphs, nil)},
+		{"glyph size too small", psf2Font(32, 0, 10, 4, glyphs, nil)},
+		{"truncated glyphs", psf2Font(32, 0, 10, 2, glyphs, nil)[:38]},
+		{"short header size", psf2Font(16, 0, 10, 2, glyphs, nil)},
+		{"no glyphs", psf2Font(32, 0, 10, 2, nil, nil)},
+	}
+	for _, tt := range tests {
+		if _, err := ParsePSF2Font(tt.data); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
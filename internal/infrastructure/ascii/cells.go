@@ -0,0 +1,81 @@
+package ascii
+
+import (
+	"ccusage-rainbow/internal/domain/interfaces"
+	"strings"
+)
+
+// quadrantBlocks are the quadrant characters indexed by their set pixels: upper left 1, upper
+// right 2, lower left 4 and lower right 8
+var quadrantBlocks = []rune(" ▘▝▀▖▌▞▛▗▚▐▜▄▙▟█")
+
+// brailleDots are the bits of the braille dots by pixel row and column
+var brailleDots = [4][2]rune{{0x01, 0x08}, {0x02, 0x10}, {0x04, 0x20}, {0x40, 0x80}}
+
+// packPixels packs rendered lines, a pixel per cell, into the cells of a finer render mode. Any
+// character other than a space is a set pixel, and cells without one stay spaces.
+func packPixels(lines []string, mode interfaces.RenderMode) []string {
+	cellWidth, cellHeight := mode.CellPixels()
+	grid := make([][]rune, len(lines))
+	width := 0
+	for i, line := range lines {
+		grid[i] = []rune(line)
+		width = max(width, len(grid[i]))
+	}
+
+	packed := make([]string, (len(grid)+cellHeight-1)/cellHeight)
+	for row := range packed {
+		var line strings.Builder
+		for column := 0; column < (width+cellWidth-1)/cellWidth; column++ {
+			x, y := column*cellWidth, row*cellHeight
+			line.WriteRune(packCell(mode, func(dx, dy int) bool {
+				return y+dy < len(grid) && x+dx < len(grid[y+dy]) && grid[y+dy][x+dx] != ' '
+			}))
+		}
+		packed[row] = line.String()
+	}
+	return packed
+}
+
+// packCell returns the character that draws the pixels of a cell, given by their offset in it
+func packCell(mode interfaces.RenderMode, pixel func(dx, dy int) bool) rune {
+	switch mode {
+	case interfaces.RenderModeHalf:
+		switch top, bottom := pixel(0, 0), pixel(0, 1); {
+		case top && bottom:
+			return '█'
+		case top:
+			return '▀'
+		case bottom:
+			return '▄'
+		default:
+			return ' '
+		}
+	case interfaces.RenderModeQuadrant:
+		index := 0
+		for bit, offset := range [][2]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+			if pixel(offset[0], offset[1]) {
+				index |= 1 << bit
+			}
+		}
+		return quadrantBlocks[index]
+	case interfaces.RenderModeBraille:
+		var dots rune
+		for dy, row := range brailleDots {
+			for dx, bit := range row {
+				if pixel(dx, dy) {
+					dots |= bit
+				}
+			}
+		}
+		if dots == 0 {
+			return ' '
+		}
+		return 0x2800 + dots
+	default:
+		if pixel(0, 0) {
+			return '█'
+		}
+		return ' '
+	}
+}
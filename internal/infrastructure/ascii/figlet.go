@@ -0,0 +1,358 @@
+package ascii
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Horizontal layout bits of a FIGlet font's full layout
+const (
+	smushEqual     = 1   // Equal characters smush into one
+	smushLowline   = 2   // An underscore is replaced by a bracket or slash
+	smushHierarchy = 4   // The character of the higher class of |, /\, [], {}, (), <> wins
+	smushPair      = 8   // Opposite brackets become a vertical bar
+	smushBigX      = 16  // /\ becomes |, \/ becomes Y and >< becomes X
+	smushHardblank = 32  // Two hardblanks smush into one
+	layoutKerning  = 64  // Characters move together until they touch
+	layoutSmushing = 128 // Characters move one column further and smush by the rules
+)
+
+// maxFigletHeight is the most rows a FIGlet glyph may have; taller fonts are corrupt files rather
+// than fonts meant for a terminal
+const maxFigletHeight = 256
+
+// figletGermanCharacters are the code points of the seven glyphs that follow ASCII in every FIGlet font
+var figletGermanCharacters = []rune{196, 214, 220, 228, 246, 252, 223}
+
+// FigletFont represents a FIGlet font loaded from a .flf file
+type FigletFont struct {
+	hardblank rune
+	height    int
+	layout    int // Full layout bits; vertical layout is irrelevant for a single line of text
+	glyphs    map[rune][][]rune
+}
+
+// LoadFigletFont reads a FIGlet font file; zipped fonts, as FIGlet 2.2 distributes some, are unpacked
+func LoadFigletFont(path string) (*FigletFont, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(data, []byte("PK")) {
+		data, err = unzipFirstFile(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid FIGlet font %s: %w", path, err)
+		}
+	}
+	font, err := ParseFigletFont(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid FIGlet font %s: %w", path, err)
+	}
+	return font, nil
+}
+
+// unzipFirstFile returns the contents of the first file in a zip archive
+func unzipFirstFile(data []byte) ([]byte, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	if len(archive.File) == 0 {
+		return nil, fmt.Errorf("empty zip archive")
+	}
+	file, err := archive.File[0].Open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	return io.ReadAll(file)
+}
+
+// ParseFigletFont parses a FIGlet font in the flf2a format: the header, the required ASCII and
+// German glyphs, and any code-tagged glyphs after them
+func ParseFigletFont(reader io.Reader) (*FigletFont, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimRight(scanner.Text(), "\r"))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("empty file")
+	}
+
+	// flf2a$ height baseline max-length old-layout comment-lines [print-direction full-layout codetag-count]
+	header := strings.Fields(lines[0])
+	if len(header) < 6 || !strings.HasPrefix(header[0], "flf2a") || len(header[0]) < 6 {
+		return nil, fmt.Errorf("missing flf2a header")
+	}
+	numbers := make([]int, len(header)-1)
+	for i, field := range header[1:] {
+		number, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid header field %q", field)
+		}
+		numbers[i] = number
+	}
+	height, oldLayout, commentLines := numbers[0], numbers[3], numbers[4]
+	if height < 1 || height > maxFigletHeight {
+		return nil, fmt.Errorf("invalid height %d", height)
+	}
+	if commentLines < 0 {
+		return nil, fmt.Errorf("invalid comment line count %d", commentLines)
+	}
+
+	font := &FigletFont{
+		hardblank: []rune(header[0][5:])[0],
+		height:    height,
+		glyphs:    make(map[rune][][]rune),
+	}
+	switch {
+	case len(numbers) >= 7:
+		font.layout = numbers[6]
+	case oldLayout < 0:
+		font.layout = 0
+	case oldLayout == 0:
+		font.layout = layoutKerning
+	default:
+		font.layout = layoutSmushing | (oldLayout & 63)
+	}
+
+	next := 1 + commentLines
+	readGlyph := func() ([][]rune, bool) {
+		if next+height > len(lines) {
+			return nil, false
+		}
+		glyph := make([][]rune, height)
+		for i := range glyph {
+			glyph[i] = []rune(stripEndmarks(lines[next+i]))
+		}
+		next += height
+		return padGlyph(glyph), true
+	}
+
+	// The required characters come in a fixed order without tags
+	required := make([]rune, 0, 95+len(figletGermanCharacters))
+	for char := rune(32); char <= 126; char++ {
+		required = append(required, char)
+	}
+	required = append(required, figletGermanCharacters...)
+	for _, char := range required {
+		glyph, ok := readGlyph()
+		if !ok {
+			break
+		}
+		font.glyphs[char] = glyph
+	}
+
+	// Code-tagged characters: a line with the code point, then the glyph
+	for next < len(lines) {
+		tag := strings.Fields(lines[next])
+		next++
+		if len(tag) == 0 {
+			continue
+		}
+		code, err := strconv.ParseInt(tag[0], 0, 64)
+		glyph, ok := readGlyph()
+		if !ok {
+			break
+		}
+		// Negative codes are font-specific and never typed
+		if err == nil && code >= 0 && code <= unicode.MaxRune {
+			font.glyphs[rune(code)] = glyph
+		}
+	}
+
+	if len(font.glyphs) == 0 {
+		return nil, fmt.Errorf("no glyphs")
+	}
+	return font, nil
+}
+
+// stripEndmarks removes trailing whitespace and the endmark character that ends every glyph row,
+// doubled on a glyph's last row
+func stripEndmarks(line string) string {
+	line = strings.TrimRightFunc(line, unicode.IsSpace)
+	if line == "" {
+		return line
+	}
+	runes := []rune(line)
+	endmark := runes[len(runes)-1]
+	return strings.TrimRight(line, string(endmark))
+}
+
+// padGlyph pads the rows of a glyph with spaces to the width of its widest row
+func padGlyph(glyph [][]rune) [][]rune {
+	width := 0
+	for _, row := range glyph {
+		width = max(width, len(row))
+	}
+	for i, row := range glyph {
+		for len(row) < width {
+			row = append(row, ' ')
+		}
+		glyph[i] = row
+	}
+	return glyph
+}
+
+// Height returns the number of rows of every glyph
+func (f *FigletFont) Height() int {
+	return f.height
+}
+
+// Render draws text in the font, joining the glyphs by the font's layout. Characters the font
+// lacks fall back to the other letter case, or are left out as FIGlet does.
+func (f *FigletFont) Render(content []rune) []string {
+	rows := make([][]rune, f.height)
+	previousWidth := 0
+	for _, char := range content {
+		glyph, ok := lookupGlyph(f.glyphs, char)
+		if !ok {
+			continue
+		}
+		width := len(glyph[0])
+		amount := f.smushAmount(rows, glyph, previousWidth, width)
+		for i, row := range rows {
+			for k := 0; k < amount; k++ {
+				column := len(row) - amount + k
+				if column < 0 {
+					continue
+				}
+				if smushed := f.smush(row[column], glyph[i][k], previousWidth, width); smushed != 0 {
+					row[column] = smushed
+				}
+			}
+			rows[i] = append(row, glyph[i][min(amount, width):]...)
+		}
+		previousWidth = width
+	}
+
+	lines := make([]string, f.height)
+	for i, row := range rows {
+		lines[i] = strings.ReplaceAll(string(row), string(f.hardblank), " ")
+	}
+	return lines
+}
+
+// smushAmount returns how many columns the next glyph can move into the rendered rows: until the
+// glyphs touch when kerning, one more where the touching characters smush
+func (f *FigletFont) smushAmount(rows [][]rune, glyph [][]rune, previousWidth, width int) int {
+	if f.layout&(layoutKerning|layoutSmushing) == 0 {
+		return 0
+	}
+	amount := width
+	for i, row := range rows {
+		// The last character drawn on the row
+		var left rune
+		boundary := 0
+		if len(row) > 0 {
+			boundary = len(row) - 1
+			for boundary > 0 && row[boundary] == ' ' {
+				boundary--
+			}
+			left = row[boundary]
+		}
+		// The first character of the glyph's row
+		var right rune
+		start := 0
+		for start < len(glyph[i]) && glyph[i][start] == ' ' {
+			start++
+		}
+		if start < len(glyph[i]) {
+			right = glyph[i][start]
+		}
+
+		rowAmount := start + len(row) - 1 - boundary
+		if left == 0 || left == ' ' {
+			rowAmount++
+		} else if right != 0 && f.smush(left, right, previousWidth, width) != 0 {
+			rowAmount++
+		}
+		amount = min(amount, rowAmount)
+	}
+	return max(amount, 0)
+}
+
+// smush returns the character two overlapping characters merge into, or 0 if they cannot merge
+func (f *FigletFont) smush(left, right rune, previousWidth, width int) rune {
+	if left == ' ' {
+		return right
+	}
+	if right == ' ' {
+		return left
+	}
+	// Glyphs of one column never overlap, and kerning only overlaps blanks
+	if previousWidth < 2 || width < 2 || f.layout&layoutSmushing == 0 {
+		return 0
+	}
+
+	rules := f.layout & 63
+	if rules == 0 {
+		// Universal smushing: a visible character wins over a hardblank, otherwise the later one wins
+		if left == f.hardblank {
+			return right
+		}
+		if right == f.hardblank {
+			return left
+		}
+		return right
+	}
+
+	if rules&smushHardblank != 0 && left == f.hardblank && right == f.hardblank {
+		return left
+	}
+	if left == f.hardblank || right == f.hardblank {
+		return 0
+	}
+	if rules&smushEqual != 0 && left == right {
+		return left
+	}
+	if rules&smushLowline != 0 {
+		if left == '_' && strings.ContainsRune(`|/\[]{}()<>`, right) {
+			return right
+		}
+		if right == '_' && strings.ContainsRune(`|/\[]{}()<>`, left) {
+			return left
+		}
+	}
+	if rules&smushHierarchy != 0 {
+		classes := []string{"|", `/\`, "[]", "{}", "()", "<>"}
+		for i, class := range classes {
+			higher := strings.Join(classes[i+1:], "")
+			if strings.ContainsRune(class, left) && strings.ContainsRune(higher, right) {
+				return right
+			}
+			if strings.ContainsRune(class, right) && strings.ContainsRune(higher, left) {
+				return left
+			}
+		}
+	}
+	if rules&smushPair != 0 {
+		switch string([]rune{left, right}) {
+		case "[]", "][", "{}", "}{", "()", ")(":
+			return '|'
+		}
+	}
+	if rules&smushBigX != 0 {
+		switch string([]rune{left, right}) {
+		case `/\`:
+			return '|'
+		case `\/`:
+			return 'Y'
+		case "><":
+			return 'X'
+		}
+	}
+	return 0
+}
@@ -0,0 +1,248 @@
+package ascii
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// parseFiglet parses an inline FIGlet font
+func parseFiglet(t *testing.T, source string) *FigletFont {
+	t.Helper()
+	font, err := ParseFigletFont(strings.NewReader(source))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return font
+}
+
+// figletSource builds a font of height 1 that draws every required character as x, followed by
+// the given lines
+func figletSource(header string, extra ...string) string {
+	lines := []string{header, "comment"}
+	for range 95 + len(figletGermanCharacters) {
+		lines = append(lines, "x@@")
+	}
+	return strings.Join(append(lines, extra...), "\n")
+}
+
+func assertGlyph(t *testing.T, font *FigletFont, char rune, want ...string) {
+	t.Helper()
+	glyph, ok := font.glyphs[char]
+	if !ok {
+		t.Errorf("%q: missing glyph", char)
+		return
+	}
+	got := make([]string, len(glyph))
+	for i, row := range glyph {
+		got[i] = string(row)
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("%q: got glyph %q, want %q", char, got, want)
+	}
+}
+
+func TestParseFigletFont(t *testing.T) {
+	// Six header fields, CRLF line ends, an endmark other than @, whitespace after endmarks and
+	// rows of different widths
+	font := parseFiglet(t, "flf2a$ 2 1 6 15 2\r\n"+
+		"comment one\r\n"+
+		"comment two\r\n"+
+		" $@\r\n"+
+		" $@@\r\n"+
+		"|_#  \r\n"+
+		"|##\r\n")
+
+	if font.height != 2 || font.hardblank != '$' {
+		t.Errorf("got height %d and hardblank %q, want 2 and '$'", font.height, font.hardblank)
+	}
+	// The old layout 15 smushes by the rules 1 to 8
+	if font.layout != layoutSmushing|15 {
+		t.Errorf("got layout %d, want %d", font.layout, layoutSmushing|15)
+	}
+	if len(font.glyphs) != 2 {
+		t.Errorf("got %d glyphs, want 2", len(font.glyphs))
+	}
+	assertGlyph(t, font, ' ', " $", " $")
+	assertGlyph(t, font, '!', "|_", "| ")
+}
+
+func TestParseFigletFontLayout(t *testing.T) {
+	tests := []struct {
+		header string
+		layout int
+	}{
+		{"flf2a$ 1 1 2 -1 1", 0},
+		{"flf2a$ 1 1 2 0 1", layoutKerning},
+		{"flf2a$ 1 1 2 24 1", layoutSmushing | smushPair | smushBigX},
+		// The full layout wins over the old one
+		{"flf2a$ 1 1 2 0 1 0 129 0", layoutSmushing | smushEqual},
+	}
+	for _, tt := range tests {
+		font := parseFiglet(t, tt.header+"\ncomment\nx@@\n")
+		if font.layout != tt.layout {
+			t.Errorf("%s: got layout %d, want %d", tt.header, font.layout, tt.layout)
+		}
+	}
+}
+
+func TestParseFigletFontCodeTags(t *testing.T) {
+	font := parseFiglet(t, figletSource("flf2a$ 1 1 2 0 1 0 64 3",
+		"0x263A  WHITE SMILING FACE",
+		":)@@",
+		"",
+		"8364 EURO SIGN",
+		"E@@",
+		"-2  font specific",
+		"?@@",
+		"U+00E9 not a code",
+		"e@@",
+		// A tag without its glyph ends the font
+		"9731 SNOWMAN",
+	))
+
+	if want := 95 + len(figletGermanCharacters) + 2; len(font.glyphs) != want {
+		t.Errorf("got %d glyphs, want %d", len(font.glyphs), want)
+	}
+	assertGlyph(t, font, '~', "x")
+	assertGlyph(t, font, 'ß', "x")
+	assertGlyph(t, font, '☺', ":)")
+	assertGlyph(t, font, '€', "E")
+	for _, char := range []rune{-2, 'é', '☃'} {
+		if _, ok := font.glyphs[char]; ok {
+			t.Errorf("%q: got a glyph, want none", char)
+		}
+	}
+}
+
+func TestParseFigletFontErrors(t *testing.T) {
+	for _, source := range []string{
+		"",
+		"flf2b$ 1 1 2 0 0\nx@@\n",
+		"flf2a 1 1 2 0 0\nx@@\n",
+		"flf2a$ 1 1 2 0\nx@@\n",
+		"flf2a$ 1 one 2 0 0\nx@@\n",
+		"flf2a$ 0 1 2 0 0\nx@@\n",
+		"flf2a$ 1000 1 2 0 0\nx@@\n",
+		"flf2a$ 1 1 3 0 -3\nx@@\n",
+		"flf2a$ 2 1 2 0 1\ncomment\nx@@\n",
+	} {
+		if _, err := ParseFigletFont(strings.NewReader(source)); err == nil {
+			t.Errorf("%q: expected an error", source)
+		}
+	}
+}
+
+func TestFigletSmush(t *testing.T) {
+	universal := &FigletFont{hardblank: '$', layout: layoutSmushing}
+	kerning := &FigletFont{hardblank: '$', layout: layoutKerning}
+	rules := func(bits int) *FigletFont { return &FigletFont{hardblank: '$', layout: layoutSmushing | bits} }
+
+	tests := []struct {
+		name  string
+		font  *FigletFont
+		left  rune
+		right rune
+		width int // Of both glyphs
+		want  rune
+	}{
+		{"blank left", kerning, ' ', 'b', 2, 'b'},
+		{"blank right", kerning, 'a', ' ', 2, 'a'},
+		{"kerning", kerning, 'a', 'b', 2, 0},
+		{"narrow glyph", universal, 'a', 'b', 1, 0},
+		// Universal smushing follows FIGlet's smushem: visible characters win over hardblanks
+		{"universal", universal, 'a', 'b', 2, 'b'},
+		{"universal hardblank left", universal, '$', 'b', 2, 'b'},
+		{"universal hardblank right", universal, 'a', '$', 2, 'a'},
+		{"equal", rules(smushEqual), 'a', 'a', 2, 'a'},
+		{"unequal", rules(smushEqual), 'a', 'b', 2, 0},
+		{"hardblank", rules(smushHardblank), '$', '$', 2, '$'},
+		{"hardblank without rule", rules(smushEqual), '$', '$', 2, 0},
+		{"hardblank and character", rules(smushEqual | smushHardblank), '$', 'a', 2, 0},
+		{"lowline left", rules(smushLowline), '_', '|', 2, '|'},
+		{"lowline right", rules(smushLowline), '/', '_', 2, '/'},
+		{"hierarchy", rules(smushHierarchy), '|', '/', 2, '/'},
+		{"hierarchy higher left", rules(smushHierarchy), '<', '[', 2, '<'},
+		{"hierarchy same class", rules(smushHierarchy), '/', '\\', 2, 0},
+		{"pair", rules(smushPair), '[', ']', 2, '|'},
+		{"reversed pair", rules(smushPair), ')', '(', 2, '|'},
+		{"big x bar", rules(smushBigX), '/', '\\', 2, '|'},
+		{"big x y", rules(smushBigX), '\\', '/', 2, 'Y'},
+		{"big x x", rules(smushBigX), '>', '<', 2, 'X'},
+	}
+	for _, tt := range tests {
+		if got := tt.font.smush(tt.left, tt.right, tt.width, tt.width); got != tt.want {
+			t.Errorf("%s: smush(%q, %q): got %q, want %q", tt.name, tt.left, tt.right, got, tt.want)
+		}
+	}
+}
+
+func TestFigletSmushAmount(t *testing.T) {
+	universal := &FigletFont{hardblank: '$', layout: layoutSmushing}
+	kerning := &FigletFont{hardblank: '$', layout: layoutKerning}
+	equal := &FigletFont{hardblank: '$', layout: layoutSmushing | smushEqual}
+
+	tests := []struct {
+		name  string
+		font  *FigletFont
+		rows  []string
+		glyph []string
+		want  int
+	}{
+		{"full width", &FigletFont{hardblank: '$'}, []string{"ab"}, []string{" c"}, 0},
+		{"first glyph", universal, []string{""}, []string{"  c"}, 2},
+		{"kerning", kerning, []string{"ab  "}, []string{"  cd"}, 4},
+		{"kerning touching", kerning, []string{"ab"}, []string{"cd"}, 0},
+		{"smushing", universal, []string{"ab"}, []string{"cd"}, 1},
+		{"smushing hardblank", universal, []string{"ab"}, []string{"$d"}, 1},
+		{"rule applies", equal, []string{"ab"}, []string{"bd"}, 1},
+		{"rule fails", equal, []string{"ab"}, []string{"cd"}, 0},
+		// The row that allows the least overlap decides
+		{"rows", universal, []string{"ab  ", "abcd"}, []string{"  ef", "ef  "}, 1},
+		{"never more than the glyph", kerning, []string{"      "}, []string{"ab"}, 2},
+	}
+	for _, tt := range tests {
+		rows := make([][]rune, len(tt.rows))
+		for i, row := range tt.rows {
+			rows[i] = []rune(row)
+		}
+		glyph := make([][]rune, len(tt.glyph))
+		for i, row := range tt.glyph {
+			glyph[i] = []rune(row)
+		}
+		previousWidth := 0
+		if len(rows[0]) > 0 {
+			previousWidth = 2
+		}
+		if got := tt.font.smushAmount(rows, glyph, previousWidth, len(glyph[0])); got != tt.want {
+			t.Errorf("%s: got %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFigletRender(t *testing.T) {
+	// Space, ! and " in a font of height 1
+	source := func(layout int) string {
+		return fmt.Sprintf("flf2a$ 1 1 3 0 1 0 %d 0\ntiny\n$@@\nab@@\n$d@@\n", layout)
+	}
+
+	tests := []struct {
+		layout  int
+		content string
+		want    string
+	}{
+		{0, `!"`, "ab d"},
+		{layoutKerning, `!"`, "ab d"},
+		{layoutSmushing, `!!`, "aab"},
+		// The b the hardblank overlaps stays visible
+		{layoutSmushing, `!"`, "abd"},
+		// Characters the font lacks are left out
+		{layoutSmushing, `!?!`, "aab"},
+	}
+	for _, tt := range tests {
+		font := parseFiglet(t, source(tt.layout))
+		if got := font.Render([]rune(tt.content)); len(got) != 1 || got[0] != tt.want {
+			t.Errorf("layout %d, %q: got %q, want %q", tt.layout, tt.content, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package ascii
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// BuiltinFont is the name of the hand-drawn font in three sizes
+const BuiltinFont = "builtin"
+
+// Font represents a font loaded from a FIGlet or bitmap font file. FIGlet fonts are drawn at
+// their own size whatever size is asked for; bitmap fonts scale like the built-in glyphs.
+type Font interface {
+	// Render returns the rows of text drawn in the font
+	Render(content []rune) []string
+}
+
+// fontLoader loads the font files whose names end in suffix
+type fontLoader struct {
+	suffix string
+	load   func(path string) (Font, error)
+}
+
+// fontLoaders load font files by their file name suffix; Linux console fonts come gzipped
+var fontLoaders = []fontLoader{
+	{".flf", func(path string) (Font, error) { return LoadFigletFont(path) }},
+	{".bdf", loadBitmapFont},
+	{".bdf.gz", loadBitmapFont},
+	{".psf", loadBitmapFont},
+	{".psf.gz", loadBitmapFont},
+	{".psfu", loadBitmapFont},
+	{".psfu.gz", loadBitmapFont},
+}
+
+// loadBitmapFont loads a BDF or PSF font file as a Font
+func loadBitmapFont(path string) (Font, error) {
+	return LoadBitmapFont(path)
+}
+
+// findLoader returns the loader for a font file name, or nil if the file is not a known font format
+func findLoader(name string) *fontLoader {
+	lower := strings.ToLower(name)
+	for i := range fontLoaders {
+		if strings.HasSuffix(lower, fontLoaders[i].suffix) {
+			return &fontLoaders[i]
+		}
+	}
+	return nil
+}
+
+// lookupGlyph returns the glyph of a character, trying the other letter case when the font lacks it
+func lookupGlyph[T any](glyphs map[rune]T, char rune) (T, bool) {
+	if glyph, ok := glyphs[char]; ok {
+		return glyph, true
+	}
+	if glyph, ok := glyphs[unicode.ToUpper(char)]; ok {
+		return glyph, true
+	}
+	glyph, ok := glyphs[unicode.ToLower(char)]
+	return glyph, ok
+}
+
+// defaultFontDir returns $XDG_CONFIG_HOME/ccusage-rainbow/fonts (or the platform equivalent),
+// or an empty string when there is no config directory
+func defaultFontDir() string {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(base, "ccusage-rainbow", "fonts")
+}
+
+// SelectFont switches to a font file given by path, or by name from the font directory;
+// an empty name or "builtin" switches back to the built-in font
+func (r *Renderer) SelectFont(name string) error {
+	if name == "" || name == BuiltinFont {
+		r.font = nil
+		return nil
+	}
+
+	path := name
+	if _, err := os.Stat(path); err != nil {
+		path = r.findFont(name)
+		if path == "" {
+			available, _ := r.ListFonts()
+			return fmt.Errorf("unknown font %q (available: %s)", name, strings.Join(available, ", "))
+		}
+	}
+
+	loader := findLoader(path)
+	if loader == nil {
+		return fmt.Errorf("unsupported font file %s (expected .flf, .bdf or .psf)", path)
+	}
+	font, err := loader.load(path)
+	if err != nil {
+		return err
+	}
+	r.font = font
+	return nil
+}
+
+// findFont returns the path of the font with the given name in the font directory, or an empty string
+func (r *Renderer) findFont(name string) string {
+	if r.fontDir == "" {
+		return ""
+	}
+	for _, loader := range fontLoaders {
+		path := filepath.Join(r.fontDir, name+loader.suffix)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// ListFonts returns the built-in font followed by the fonts in the font directory, by name
+func (r *Renderer) ListFonts() ([]string, error) {
+	fonts := []string{BuiltinFont}
+	if r.fontDir == "" {
+		return fonts, nil
+	}
+	entries, err := os.ReadDir(r.fontDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return fonts, nil
+	}
+	if err != nil {
+		return fonts, err
+	}
+
+	// Files of one name in several formats select the first format, so list the name once
+	var names []string
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		if loader := findLoader(entry.Name()); loader != nil && !entry.IsDir() {
+			name := entry.Name()[:len(entry.Name())-len(loader.suffix)]
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return append(fonts, names...), nil
+}
+
+// renderFont draws text in the selected font file
+func (r *Renderer) renderFont(content string) string {
+	return strings.Join(r.font.Render([]rune(content)), "\n")
+}
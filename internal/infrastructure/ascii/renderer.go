@@ -13,6 +13,9 @@ type Renderer struct {
 	smallPatterns  map[rune][]string
 	mediumPatterns map[rune][]string
 	largePatterns  map[rune][]string
+	mode           interfaces.RenderMode // How the pixels of the built-in and bitmap glyphs are packed into cells
+	font           Font                  // Selected font file, nil for the built-in font
+	fontDir        string                // Directory fonts are looked up in by name
 }
 
 // NewRenderer creates a new ASCII renderer
@@ -21,18 +24,23 @@ func NewRenderer() *Renderer {
 		smallPatterns:  getSmallLetterPatterns(),
 		mediumPatterns: getMediumLetterPatterns(),
 		largePatterns:  getLargeLetterPatterns(),
+		mode:           interfaces.RenderModeBlock,
+		fontDir:        defaultFontDir(),
 	}
 }
 
 // RenderPlain renders text as plain ASCII art without colors
 func (r *Renderer) RenderPlain(text *entities.Text) (string, error) {
-	content := strings.ToUpper(text.Content)
+	if r.font != nil {
+		return r.renderFont(text.Content), nil
+	}
+	content := []rune(text.Content)
 
 	var result []string
 	for i := 0; i < 7; i++ { // 7 rows per character
 		var line string
 		for j, char := range content {
-			if patterns, ok := r.mediumPatterns[char]; ok {
+			if patterns, ok := lookupGlyph(r.mediumPatterns, char); ok {
 				line += patterns[i]
 			} else {
 				line += "         " // Default spacing for unknown characters (9 chars)
@@ -48,10 +56,58 @@ func (r *Renderer) RenderPlain(text *entities.Text) (string, error) {
 	return strings.Join(result, "\n"), nil
 }
 
-// RenderPlainWithSize renders text with specified font size
+// RenderPlainWithSize renders text with specified font size. The glyphs are drawn as pixels, as
+// many rows of them as the render mode packs into the rows of the size, from the largest
+// hand-drawn size no taller than that, or from the selected bitmap font, scaled to fit. FIGlet
+// glyphs are characters rather than pixels and keep their own size.
 func (r *Renderer) RenderPlainWithSize(text *entities.Text, size interfaces.FontSize) (string, error) {
-	content := strings.ToUpper(text.Content)
+	bitmap, isBitmap := r.font.(*BitmapFont)
+	if r.font != nil && !isBitmap {
+		return r.renderFont(text.Content), nil
+	}
+	cellWidth, cellHeight := r.mode.CellPixels()
+	pixelRows := size.Rows() * cellHeight
+
+	var lines []string
+	var baseRows int
+	if isBitmap {
+		lines, baseRows = bitmap.Render([]rune(text.Content)), bitmap.Height()
+	} else {
+		base := builtinSizeFor(interfaces.FontSizeForRows(pixelRows))
+		lines, baseRows = r.renderBuiltin([]rune(text.Content), base), base.Rows()
+	}
+
+	// A glyph pixel fills a cell in block mode; smaller pixels keep that shape
+	yFactor := float64(pixelRows) / float64(baseRows)
+	xFactor := yFactor * float64(cellWidth) / float64(cellHeight)
+	if xFactor != 1 || yFactor != 1 {
+		lines = scaleLines(lines, xFactor, yFactor)
+	}
+	if r.mode != interfaces.RenderModeBlock {
+		lines = packPixels(lines, r.mode)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// SelectRenderMode chooses how the pixels of the built-in and bitmap glyphs are packed into cells
+func (r *Renderer) SelectRenderMode(mode interfaces.RenderMode) {
+	r.mode = mode
+}
+
+// builtinSizeFor returns the largest hand-drawn size no taller than size, or the small size
+func builtinSizeFor(size interfaces.FontSize) interfaces.FontSize {
+	switch rows := size.Rows(); {
+	case rows >= interfaces.FontSizeLarge.Rows():
+		return interfaces.FontSizeLarge
+	case rows >= interfaces.FontSizeMedium.Rows():
+		return interfaces.FontSizeMedium
+	default:
+		return interfaces.FontSizeSmall
+	}
+}
 
+// renderBuiltin renders text with the glyphs of a hand-drawn size
+func (r *Renderer) renderBuiltin(content []rune, size interfaces.FontSize) []string {
 	var patterns map[rune][]string
 	var rows int
 	var defaultSpacing string
@@ -68,7 +124,7 @@ func (r *Renderer) RenderPlainWithSize(text *entities.Text, size interfaces.Font
 	case interfaces.FontSizeLarge:
 		patterns = r.largePatterns
 		rows = 10
-		defaultSpacing = "             " // 13 chars
+		defaultSpacing = "              " // 14 chars
 	default:
 		patterns = r.mediumPatterns
 		rows = 7
@@ -79,18 +135,19 @@ func (r *Renderer) RenderPlainWithSize(text *entities.Text, size interfaces.Font
 	for i := 0; i < rows; i++ {
 		var line string
 		for j, char := range content {
-			if charPatterns, ok := patterns[char]; ok {
+			// Lowercase letters without a glyph of their own, unlike k, are drawn in uppercase
+			if charPatterns, ok := lookupGlyph(patterns, char); ok {
 				line += charPatterns[i]
 			} else {
 				line += defaultSpacing
 			}
 			// Only add space between characters, not after the last one
 			if j < len(content)-1 {
-				nextChar := rune(content[j+1])
+				nextChar := content[j+1]
 				currentChar := char
 
-				// Use smaller spacing around decimal points
-				if currentChar == '.' || nextChar == '.' {
+				// Use smaller spacing around decimal points, digit group separators and time separators
+				if isNarrow(currentChar) || isNarrow(nextChar) {
 					switch size {
 					case interfaces.FontSizeSmall:
 						line += " " // 1 space around decimal point for small font
@@ -118,7 +175,12 @@ func (r *Renderer) RenderPlainWithSize(text *entities.Text, size interfaces.Font
 		result = append(result, line)
 	}
 
-	return strings.Join(result, "\n"), nil
+	return result
+}
+
+// isNarrow reports whether a character is drawn narrow and gets smaller spacing around it
+func isNarrow(char rune) bool {
+	return char == '.' || char == ',' || char == ':'
 }
 
 // GetDisplayWidth calculates the actual display width of rendered text
@@ -312,6 +374,285 @@ func getMediumLetterPatterns() map[rune][]string {
 			"███   ███",
 			"███   ███",
 		},
+		'A': {
+			" ███████ ",
+			"███   ███",
+			"███   ███",
+			"█████████",
+			"███   ███",
+			"███   ███",
+			"███   ███",
+		},
+		'D': {
+			"████████ ",
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			"████████ ",
+		},
+		'I': {
+			" ███████ ",
+			"   ███   ",
+			"   ███   ",
+			"   ███   ",
+			"   ███   ",
+			"   ███   ",
+			" ███████ ",
+		},
+		'N': {
+			"███   ███",
+			"████  ███",
+			"█████ ███",
+			"███ █████",
+			"███  ████",
+			"███   ███",
+			"███   ███",
+		},
+		'G': {
+			" ███████ ",
+			"███   ███",
+			"███      ",
+			"███  ████",
+			"███   ███",
+			"███   ███",
+			" ███████ ",
+		},
+		'€': {
+			"   ██████",
+			"  ██     ",
+			"███████  ",
+			" ██      ",
+			"███████  ",
+			"  ██     ",
+			"   ██████",
+		},
+		'¥': {
+			"███   ███",
+			" ███ ███ ",
+			"  █████  ",
+			"█████████",
+			"   ███   ",
+			"█████████",
+			"   ███   ",
+		},
+		'£': {
+			"   █████ ",
+			"  ███   █",
+			"  ███    ",
+			"███████  ",
+			"  ███    ",
+			"  ███    ",
+			"█████████",
+		},
+		',': {
+			"      ",
+			"      ",
+			"      ",
+			"      ",
+			" ███  ",
+			" ███  ",
+			" ██   ",
+		},
+		':': {
+			"      ",
+			" ███  ",
+			" ███  ",
+			"      ",
+			" ███  ",
+			" ███  ",
+			"      ",
+		},
+		'X': {
+			"███   ███",
+			" ███ ███ ",
+			"  █████  ",
+			"   ███   ",
+			"  █████  ",
+			" ███ ███ ",
+			"███   ███",
+		},
+		'B': {
+			"████████ ",
+			"███   ███",
+			"███   ███",
+			"████████ ",
+			"███   ███",
+			"███   ███",
+			"████████ ",
+		},
+		'C': {
+			" ████████",
+			"███      ",
+			"███      ",
+			"███      ",
+			"███      ",
+			"███      ",
+			" ████████",
+		},
+		'F': {
+			"█████████",
+			"███      ",
+			"███      ",
+			"███████  ",
+			"███      ",
+			"███      ",
+			"███      ",
+		},
+		'J': {
+			"      ███",
+			"      ███",
+			"      ███",
+			"      ███",
+			"      ███",
+			"███   ███",
+			" ███████ ",
+		},
+		'K': {
+			"███   ███",
+			"███  ███ ",
+			"███ ███  ",
+			"██████   ",
+			"███ ███  ",
+			"███  ███ ",
+			"███   ███",
+		},
+		'M': {
+			"███   ███",
+			"████ ████",
+			"█████████",
+			"███ █ ███",
+			"███   ███",
+			"███   ███",
+			"███   ███",
+		},
+		'P': {
+			"████████ ",
+			"███   ███",
+			"███   ███",
+			"████████ ",
+			"███      ",
+			"███      ",
+			"███      ",
+		},
+		'Q': {
+			" ███████ ",
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			"███ █ ███",
+			"███  ███ ",
+			" ████ ███",
+		},
+		'S': {
+			" ████████",
+			"███      ",
+			"███      ",
+			" ███████ ",
+			"      ███",
+			"      ███",
+			"████████ ",
+		},
+		'T': {
+			"█████████",
+			"   ███   ",
+			"   ███   ",
+			"   ███   ",
+			"   ███   ",
+			"   ███   ",
+			"   ███   ",
+		},
+		'U': {
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			" ███████ ",
+		},
+		'V': {
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			" ███ ███ ",
+			"  █████  ",
+			"   ███   ",
+		},
+		'W': {
+			"███   ███",
+			"███   ███",
+			"███   ███",
+			"███ █ ███",
+			"█████████",
+			"████ ████",
+			"███   ███",
+		},
+		'Y': {
+			"███   ███",
+			" ███ ███ ",
+			"  █████  ",
+			"   ███   ",
+			"   ███   ",
+			"   ███   ",
+			"   ███   ",
+		},
+		'Z': {
+			"█████████",
+			"     ███ ",
+			"    ███  ",
+			"   ███   ",
+			"  ███    ",
+			" ███     ",
+			"█████████",
+		},
+		'%': {
+			"███   ███",
+			"███  ███ ",
+			"    ███  ",
+			"   ███   ",
+			"  ███    ",
+			" ███  ███",
+			"███   ███",
+		},
+		'-': {
+			"         ",
+			"         ",
+			"         ",
+			" ███████ ",
+			"         ",
+			"         ",
+			"         ",
+		},
+		'+': {
+			"         ",
+			"   ███   ",
+			"   ███   ",
+			"█████████",
+			"   ███   ",
+			"   ███   ",
+			"         ",
+		},
+		'/': {
+			"      ███",
+			"     ███ ",
+			"    ███  ",
+			"   ███   ",
+			"  ███    ",
+			" ███     ",
+			"███      ",
+		},
+		'k': {
+			"███      ",
+			"███      ",
+			"███  ███ ",
+			"███ ███  ",
+			"██████   ",
+			"███ ███  ",
+			"███  ███ ",
+		},
 	}
 }
 
@@ -336,10 +677,41 @@ func getSmallLetterPatterns() map[rune][]string {
 		'O': {" █████ ", "██   ██", "██   ██", "██   ██", " █████ "},
 		'L': {"██     ", "██     ", "██     ", "██     ", "███████"},
 		'H': {"██   ██", "██   ██", "███████", "██   ██", "██   ██"},
+		'A': {" █████ ", "██   ██", "███████", "██   ██", "██   ██"},
+		'D': {"██████ ", "██   ██", "██   ██", "██   ██", "██████ "},
+		'I': {"███████", "  ███  ", "  ███  ", "  ███  ", "███████"},
+		'N': {"██   ██", "███  ██", "██ █ ██", "██  ███", "██   ██"},
+		'G': {" █████ ", "██     ", "██  ███", "██   ██", " █████ "},
+		'€': {"  █████", " ██    ", "█████  ", " ██    ", "  █████"},
+		'¥': {"██   ██", " ██ ██ ", "███████", "  ███  ", "  ███  "},
+		'£': {"  ████ ", " ██    ", "█████  ", " ██    ", "███████"},
+		',': {"       ", "       ", "       ", "  ██   ", " ██    "},
+		':': {"       ", "  ██   ", "       ", "  ██   ", "       "},
+		'X': {"██   ██", " ██ ██ ", "  ███  ", " ██ ██ ", "██   ██"},
+		'B': {"██████ ", "██   ██", "██████ ", "██   ██", "██████ "},
+		'C': {" ██████", "██     ", "██     ", "██     ", " ██████"},
+		'F': {"███████", "██     ", "██████ ", "██     ", "██     "},
+		'J': {"     ██", "     ██", "     ██", "██   ██", " █████ "},
+		'K': {"██   ██", "██  ██ ", "█████  ", "██  ██ ", "██   ██"},
+		'M': {"██   ██", "███ ███", "██ █ ██", "██   ██", "██   ██"},
+		'P': {"██████ ", "██   ██", "██████ ", "██     ", "██     "},
+		'Q': {" █████ ", "██   ██", "██   ██", "██  ██ ", " ███ ██"},
+		'S': {" ██████", "██     ", " █████ ", "     ██", "██████ "},
+		'T': {"███████", "  ███  ", "  ███  ", "  ███  ", "  ███  "},
+		'U': {"██   ██", "██   ██", "██   ██", "██   ██", " █████ "},
+		'V': {"██   ██", "██   ██", "██   ██", " ██ ██ ", "  ███  "},
+		'W': {"██   ██", "██   ██", "██ █ ██", "███ ███", "██   ██"},
+		'Y': {"██   ██", " ██ ██ ", "  ███  ", "  ███  ", "  ███  "},
+		'Z': {"███████", "    ██ ", "  ███  ", " ██    ", "███████"},
+		'%': {"██   ██", "    ██ ", "  ███  ", " ██    ", "██   ██"},
+		'-': {"       ", "       ", " █████ ", "       ", "       "},
+		'+': {"       ", "  ███  ", "███████", "  ███  ", "       "},
+		'/': {"     ██", "    ██ ", "  ███  ", " ██    ", "██     "},
+		'k': {"██     ", "██   ██", "██  ██ ", "█████  ", "██   ██"},
 	}
 }
 
-// getLargeLetterPatterns returns large-size ASCII art patterns (10x13)
+// getLargeLetterPatterns returns large-size ASCII art patterns (10x14)
 func getLargeLetterPatterns() map[rune][]string {
 	return map[rune][]string{
 		'$': {
@@ -499,64 +871,436 @@ func getLargeLetterPatterns() map[rune][]string {
 			"              ",
 		},
 		'E': {
-			"█████████████",
-			"████         ",
-			"████         ",
-			"████         ",
-			"█████████    ",
-			"████         ",
-			"████         ",
-			"████         ",
-			"████         ",
-			"█████████████",
+			"█████████████ ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"█████████     ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"█████████████ ",
 		},
 		'R': {
-			"████████████ ",
-			"████     ████",
-			"████     ████",
-			"████     ████",
-			"████████████ ",
-			"████   ████  ",
-			"████    ████ ",
-			"████     ████",
-			"████     ████",
-			"████     ████",
+			"████████████  ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████████████  ",
+			"████   ████   ",
+			"████    ████  ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
 		},
 		'O': {
-			"  █████████  ",
-			" ████   ████ ",
-			"████     ████",
-			"████     ████",
-			"████     ████",
-			"████     ████",
-			"████     ████",
-			"████     ████",
-			" ████   ████ ",
-			"  █████████  ",
+			"  █████████   ",
+			" ████   ████  ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			" ████   ████  ",
+			"  █████████   ",
 		},
 		'L': {
-			"████         ",
-			"████         ",
-			"████         ",
-			"████         ",
-			"████         ",
-			"████         ",
-			"████         ",
-			"████         ",
-			"████         ",
-			"█████████████",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"█████████████ ",
 		},
 		'H': {
-			"████     ████",
-			"████     ████",
-			"████     ████",
-			"████     ████",
-			"█████████████",
-			"████     ████",
-			"████     ████",
-			"████     ████",
-			"████     ████",
-			"████     ████",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"█████████████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+		},
+		'A': {
+			"  █████████   ",
+			" ████   ████  ",
+			"████     ████ ",
+			"████     ████ ",
+			"█████████████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+		},
+		'D': {
+			"███████████   ",
+			"████    ████  ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████    ████  ",
+			"███████████   ",
+		},
+		'I': {
+			"█████████████ ",
+			"    █████     ",
+			"    █████     ",
+			"    █████     ",
+			"    █████     ",
+			"    █████     ",
+			"    █████     ",
+			"    █████     ",
+			"    █████     ",
+			"█████████████ ",
+		},
+		'N': {
+			"████     ████ ",
+			"█████    ████ ",
+			"██████   ████ ",
+			"███████  ████ ",
+			"████ ███ ████ ",
+			"████  ███████ ",
+			"████   ██████ ",
+			"████    █████ ",
+			"████     ████ ",
+			"████     ████ ",
+		},
+		'G': {
+			"  █████████   ",
+			" ████   ████  ",
+			"████          ",
+			"████          ",
+			"████   ██████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			" ████   ████  ",
+			"  █████████   ",
+		},
+		'€': {
+			"     █████████",
+			"   ████       ",
+			"  ████        ",
+			"██████████    ",
+			" ████         ",
+			" ████         ",
+			"██████████    ",
+			"  ████        ",
+			"   ████       ",
+			"     █████████",
+		},
+		'¥': {
+			"████      ████",
+			" ████    ████ ",
+			"  ████  ████  ",
+			"   ████████   ",
+			"██████████████",
+			"     ████     ",
+			"██████████████",
+			"     ████     ",
+			"     ████     ",
+			"     ████     ",
+		},
+		'£': {
+			"     ███████  ",
+			"    ████  ████",
+			"    ████      ",
+			"    ████      ",
+			"██████████    ",
+			"    ████      ",
+			"    ████      ",
+			"   ████       ",
+			"  ████        ",
+			"██████████████",
+		},
+		',': {
+			"         ",
+			"         ",
+			"         ",
+			"         ",
+			"         ",
+			"         ",
+			"         ",
+			" ██████  ",
+			" ██████  ",
+			"   ███   ",
+		},
+		':': {
+			"         ",
+			"         ",
+			" ██████  ",
+			" ██████  ",
+			"         ",
+			"         ",
+			" ██████  ",
+			" ██████  ",
+			"         ",
+			"         ",
+		},
+		'X': {
+			"████     ████ ",
+			" ████   ████  ",
+			"  ████ ████   ",
+			"   ███████    ",
+			"    █████     ",
+			"    █████     ",
+			"   ███████    ",
+			"  ████ ████   ",
+			" ████   ████  ",
+			"████     ████ ",
+		},
+		'B': {
+			"███████████   ",
+			"████    ████  ",
+			"████     ████ ",
+			"████    ████  ",
+			"███████████   ",
+			"████    ████  ",
+			"████     ████ ",
+			"████     ████ ",
+			"████    ████  ",
+			"███████████   ",
+		},
+		'C': {
+			"  ███████████ ",
+			" ████         ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			" ████         ",
+			"  ███████████ ",
+		},
+		'F': {
+			"█████████████ ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"██████████    ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+		},
+		'J': {
+			"         ████ ",
+			"         ████ ",
+			"         ████ ",
+			"         ████ ",
+			"         ████ ",
+			"         ████ ",
+			"         ████ ",
+			"████     ████ ",
+			" ████   ████  ",
+			"  █████████   ",
+		},
+		'K': {
+			"████     ████ ",
+			"████    ████  ",
+			"████   ████   ",
+			"████  ████    ",
+			"████ ████     ",
+			"█████████     ",
+			"████  ████    ",
+			"████   ████   ",
+			"████    ████  ",
+			"████     ████ ",
+		},
+		'M': {
+			"████     ████ ",
+			"█████   █████ ",
+			"██████ ██████ ",
+			"████ ███ ████ ",
+			"████  █  ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+		},
+		'P': {
+			"████████████  ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████████████  ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+			"████          ",
+		},
+		'Q': {
+			"  █████████   ",
+			" ████   ████  ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████  ██ ████ ",
+			"████   ██████ ",
+			" ████   ████  ",
+			"  ██████  ███ ",
+		},
+		'S': {
+			"  ███████████ ",
+			" ████         ",
+			"████          ",
+			" ████         ",
+			"  █████████   ",
+			"        ████  ",
+			"         ████ ",
+			"         ████ ",
+			"        ████  ",
+			"███████████   ",
+		},
+		'T': {
+			"█████████████ ",
+			"    █████     ",
+			"    █████     ",
+			"    █████     ",
+			"    █████     ",
+			"    █████     ",
+			"    █████     ",
+			"    █████     ",
+			"    █████     ",
+			"    █████     ",
+		},
+		'U': {
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			" ████   ████  ",
+			"  █████████   ",
+		},
+		'V': {
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			" ████   ████  ",
+			" ████   ████  ",
+			"  ████ ████   ",
+			"   ███████    ",
+			"    █████     ",
+		},
+		'W': {
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████     ████ ",
+			"████  █  ████ ",
+			"████ ███ ████ ",
+			"██████ ██████ ",
+			"█████   █████ ",
+			"████     ████ ",
+		},
+		'Y': {
+			"████     ████ ",
+			" ████   ████  ",
+			"  ████ ████   ",
+			"   ███████    ",
+			"    █████     ",
+			"    █████     ",
+			"    █████     ",
+			"    █████     ",
+			"    █████     ",
+			"    █████     ",
+		},
+		'Z': {
+			"█████████████ ",
+			"        ████  ",
+			"       ████   ",
+			"      ████    ",
+			"     ████     ",
+			"    ████      ",
+			"   ████       ",
+			"  ████        ",
+			" ████         ",
+			"█████████████ ",
+		},
+		'%': {
+			"█████     ███ ",
+			"█████    ███  ",
+			"        ███   ",
+			"       ███    ",
+			"      ███     ",
+			"     ███      ",
+			"    ███       ",
+			"   ███        ",
+			"  ███   █████ ",
+			" ███    █████ ",
+		},
+		'-': {
+			"              ",
+			"              ",
+			"              ",
+			"              ",
+			"  █████████   ",
+			"  █████████   ",
+			"              ",
+			"              ",
+			"              ",
+			"              ",
+		},
+		'+': {
+			"              ",
+			"    █████     ",
+			"    █████     ",
+			"    █████     ",
+			"█████████████ ",
+			"█████████████ ",
+			"    █████     ",
+			"    █████     ",
+			"    █████     ",
+			"              ",
+		},
+		'/': {
+			"         ████ ",
+			"        ████  ",
+			"       ████   ",
+			"      ████    ",
+			"     ████     ",
+			"    ████      ",
+			"   ████       ",
+			"  ████        ",
+			" ████         ",
+			"████          ",
+		},
+		'k': {
+			"████          ",
+			"████          ",
+			"████          ",
+			"████    ████  ",
+			"████   ████   ",
+			"████  ████    ",
+			"█████████     ",
+			"████  ████    ",
+			"████   ████   ",
+			"████    ████  ",
 		},
 	}
 }
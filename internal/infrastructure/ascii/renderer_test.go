@@ -0,0 +1,150 @@
+package ascii
+
+import (
+	"ccusage-rainbow/internal/domain/entities"
+	"ccusage-rainbow/internal/domain/interfaces"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// builtinCharacters are the characters every built-in table must draw
+const builtinCharacters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789 $€¥£.,:%-+/k"
+
+func TestBuiltinGlyphSizes(t *testing.T) {
+	tables := []struct {
+		name        string
+		patterns    map[rune][]string
+		rows        int
+		width       int
+		narrowWidth int
+	}{
+		{"small", getSmallLetterPatterns(), 5, 7, 7},
+		{"medium", getMediumLetterPatterns(), 7, 9, 6},
+		{"large", getLargeLetterPatterns(), 10, 14, 9},
+	}
+
+	for _, table := range tables {
+		for _, char := range builtinCharacters {
+			if _, ok := table.patterns[char]; !ok {
+				t.Errorf("%s: missing glyph %q", table.name, char)
+			}
+		}
+
+		for char, glyph := range table.patterns {
+			if len(glyph) != table.rows {
+				t.Errorf("%s %q: got %d rows, want %d", table.name, char, len(glyph), table.rows)
+				continue
+			}
+			width := table.width
+			if isNarrow(char) {
+				width = table.narrowWidth
+			}
+			for i, row := range glyph {
+				if got := utf8.RuneCountInString(row); got != width {
+					t.Errorf("%s %q row %d: got width %d, want %d", table.name, char, i, got, width)
+				}
+			}
+		}
+	}
+}
+
+func TestRenderPlainWithSizeLetterCase(t *testing.T) {
+	renderer := NewRenderer()
+	render := func(content string) string {
+		t.Helper()
+		rendered, err := renderer.RenderPlainWithSize(&entities.Text{Content: content}, interfaces.FontSizeMedium)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rendered
+	}
+
+	// k has a glyph of its own for thousands, other lowercase letters are drawn in uppercase
+	if render("12k") == render("12K") {
+		t.Error("12k rendered the same as 12K")
+	}
+	if render("max") != render("MAX") {
+		t.Error("max rendered differently from MAX")
+	}
+	if rendered := render("M"); strings.TrimSpace(rendered) == "" {
+		t.Error("M rendered blank")
+	}
+}
+
+func TestRenderModes(t *testing.T) {
+	tests := []struct {
+		mode  interfaces.RenderMode
+		cells string // Characters the mode may draw with
+	}{
+		{interfaces.RenderModeBlock, " █"},
+		{interfaces.RenderModeHalf, " ▀▄█"},
+		{interfaces.RenderModeQuadrant, string(quadrantBlocks)},
+		{interfaces.RenderModeBraille, " "}, // and the braille block
+	}
+
+	text := &entities.Text{Content: "$12.5k"}
+	block := NewRenderer()
+	blockRendered, err := block.RenderPlainWithSize(text, interfaces.FontSizeSmall)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockWidth := block.GetDisplayWidth(blockRendered)
+
+	for _, test := range tests {
+		renderer := NewRenderer()
+		renderer.SelectRenderMode(test.mode)
+		rendered, err := renderer.RenderPlainWithSize(text, interfaces.FontSizeSmall)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Every mode fills the same rows; finer pixels must not change the shape
+		lines := strings.Split(rendered, "\n")
+		if len(lines) != interfaces.FontSizeSmall.Rows() {
+			t.Errorf("%s: got %d rows, want %d", test.mode, len(lines), interfaces.FontSizeSmall.Rows())
+		}
+		if width := renderer.GetDisplayWidth(rendered); width < blockWidth-2 || width > blockWidth+2 {
+			t.Errorf("%s: got width %d, want about %d", test.mode, width, blockWidth)
+		}
+		for _, char := range strings.ReplaceAll(rendered, "\n", "") {
+			braille := test.mode == interfaces.RenderModeBraille && char >= 0x2800 && char <= 0x28FF
+			if !strings.ContainsRune(test.cells, char) && !braille {
+				t.Errorf("%s: unexpected character %q", test.mode, char)
+				break
+			}
+		}
+	}
+}
+
+func TestRenderPlainWithSizeFontFiles(t *testing.T) {
+	// A diagonal 2 pixels square
+	bitmap := &BitmapFont{height: 2, glyphs: map[rune][]string{'A': {"█ ", " █"}}}
+	// A space drawn as ab
+	figlet := parseFiglet(t, "flf2a$ 1 1 3 0 1\ncomment\nab@@\n")
+
+	tests := []struct {
+		font    Font
+		mode    interfaces.RenderMode
+		content string
+		want    string
+	}{
+		// Bitmap pixels scale to the rows of the size like the built-in glyphs
+		{bitmap, interfaces.RenderModeBlock, "A", "██   \n██   \n  ███\n  ███\n  ███"},
+		{bitmap, interfaces.RenderModeHalf, "A", "██   \n██   \n▀▀▄▄▄\n  ███\n  ███"},
+		// FIGlet glyphs are characters and keep their own size
+		{figlet, interfaces.RenderModeBraille, " ", "ab"},
+	}
+	for _, tt := range tests {
+		renderer := NewRenderer()
+		renderer.font = tt.font
+		renderer.SelectRenderMode(tt.mode)
+		rendered, err := renderer.RenderPlainWithSize(&entities.Text{Content: tt.content}, interfaces.FontSizeSmall)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rendered != tt.want {
+			t.Errorf("%T in %s: got\n%s\nwant\n%s", tt.font, tt.mode, rendered, tt.want)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package ascii
+
+import (
+	"math"
+	"strings"
+)
+
+// scaleLines scales rendered lines by a factor across and a factor down with nearest-neighbour
+// sampling; factors below 1 drop columns or rows
+func scaleLines(lines []string, xFactor, yFactor float64) []string {
+	grid := make([][]rune, len(lines))
+	width := 0
+	for i, line := range lines {
+		grid[i] = []rune(line)
+		width = max(width, len(grid[i]))
+	}
+	if len(grid) == 0 || width == 0 {
+		return lines
+	}
+
+	height := int(math.Round(float64(len(grid)) * yFactor))
+	scaledWidth := int(math.Round(float64(width) * xFactor))
+	// Each scaled cell takes the source cell under its centre
+	source := func(i, limit int, factor float64) int {
+		return min(int((float64(i)+0.5)/factor), limit-1)
+	}
+
+	scaled := make([]string, height)
+	for y := range scaled {
+		row := grid[source(y, len(grid), yFactor)]
+		var line strings.Builder
+		for x := 0; x < scaledWidth; x++ {
+			if column := source(x, width, xFactor); column < len(row) {
+				line.WriteRune(row[column])
+			} else {
+				line.WriteByte(' ')
+			}
+		}
+		scaled[y] = line.String()
+	}
+	return scaled
+}
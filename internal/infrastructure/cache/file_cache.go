@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"ccusage-rainbow/internal/domain/entities"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileCache implements the CostCache interface with JSON files under the user cache directory
+type FileCache struct {
+	dir string
+}
+
+// NewFileCache creates a new file cache in $XDG_CACHE_HOME/ccusage-rainbow (or the platform equivalent)
+func NewFileCache() *FileCache {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		// No usable cache directory, caching is disabled
+		return &FileCache{}
+	}
+	return &FileCache{
+		dir: filepath.Join(base, "ccusage-rainbow"),
+	}
+}
+
+// Load returns the cached data for key, or nil if there is none
+func (c *FileCache) Load(key string) (*entities.CachedCostResponse, error) {
+	if c.dir == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(c.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var cached entities.CachedCostResponse
+	if err := json.Unmarshal(data, &cached); err != nil || cached.Response == nil {
+		// A corrupt cache is as good as no cache
+		return nil, nil
+	}
+	return &cached, nil
+}
+
+// Save stores data for key
+func (c *FileCache) Save(key string, cached *entities.CachedCostResponse) error {
+	if c.dir == "" {
+		return errors.New("no user cache directory available")
+	}
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a concurrent reader never sees a partial file
+	tmp, err := os.CreateTemp(c.dir, "cost-*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+// path returns the cache file of a key
+func (c *FileCache) path(key string) string {
+	safe := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, key)
+	return filepath.Join(c.dir, "cost-"+safe+".json")
+}
@@ -10,19 +10,43 @@ import (
 // Animator implements the ColorAnimator interface
 type Animator struct {
 	rainbowColors []string
+	palettes      map[entities.Palette][]string
 }
 
 // NewAnimator creates a new color animator
 func NewAnimator() *Animator {
+	rainbowColors := []string{
+		"#FF0000", // Red
+		"#FF8000", // Orange
+		"#FFFF00", // Yellow
+		"#00FF00", // Green
+		"#0080FF", // Blue
+		"#4000FF", // Indigo
+		"#8000FF", // Violet
+	}
+	// Every palette has seven colors, matching the animation's cycle
 	return &Animator{
-		rainbowColors: []string{
-			"#FF0000", // Red
-			"#FF8000", // Orange
-			"#FFFF00", // Yellow
-			"#00FF00", // Green
-			"#0080FF", // Blue
-			"#4000FF", // Indigo
-			"#8000FF", // Violet
+		rainbowColors: rainbowColors,
+		palettes: map[entities.Palette][]string{
+			entities.PaletteRainbow: rainbowColors,
+			entities.PaletteWarning: {
+				"#FFD700", // Gold
+				"#FFC000", // Amber
+				"#FFA500", // Orange
+				"#FF8C00", // Dark orange
+				"#FFA500", // Orange
+				"#FFC000", // Amber
+				"#FFE066", // Light gold
+			},
+			entities.PaletteCritical: {
+				"#FF0000", // Red
+				"#CC0000", // Dark red
+				"#990000", // Maroon
+				"#FF3333", // Light red
+				"#FF0000", // Red
+				"#FF6666", // Pink red
+				"#B30000", // Crimson
+			},
 		},
 	}
 }
@@ -31,17 +55,27 @@ func NewAnimator() *Animator {
 func (a *Animator) ApplyRainbowColors(asciiArt string, animation *entities.RainbowAnimation) string {
 	var result strings.Builder
 	colorIndex := animation.GetOffset()
+	colors, ok := a.palettes[animation.Palette]
+	if !ok {
+		colors = a.rainbowColors
+	}
 
 	for _, char := range asciiArt {
-		if char == ' ' || char == '\n' {
+		if isBlank(char) {
 			result.WriteRune(char)
 		} else {
-			colorIdx := colorIndex % len(a.rainbowColors)
-			style := lipgloss.NewStyle().Foreground(lipgloss.Color(a.rainbowColors[colorIdx]))
+			colorIdx := colorIndex % len(colors)
+			style := lipgloss.NewStyle().Foreground(lipgloss.Color(colors[colorIdx]))
 			result.WriteString(style.Render(string(char)))
-			colorIndex = (colorIndex + 1) % len(a.rainbowColors)
+			colorIndex = (colorIndex + 1) % len(colors)
 		}
 	}
 
 	return result.String()
 }
+
+// isBlank reports whether a character draws nothing and needs no color: spaces, line breaks and
+// the braille pattern without dots
+func isBlank(char rune) bool {
+	return char == ' ' || char == '\n' || char == '\u2800'
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"ccusage-rainbow/internal/domain/entities"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// BudgetFile implements the BudgetProvider interface with a JSON config file
+type BudgetFile struct {
+	defaultPath string
+}
+
+// NewBudgetFile creates a new budget file provider defaulting to $XDG_CONFIG_HOME/ccusage-rainbow/budget.json
+// (or the platform equivalent)
+func NewBudgetFile() *BudgetFile {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		// No config directory, a budget must be given explicitly
+		return &BudgetFile{}
+	}
+	return &BudgetFile{
+		defaultPath: filepath.Join(base, "ccusage-rainbow", "budget.json"),
+	}
+}
+
+// LoadBudget reads the budget from a config file, or from the default location when path is empty.
+// It returns nil without an error when no path is given and no default file exists.
+func (f *BudgetFile) LoadBudget(path string) (*entities.Budget, error) {
+	explicit := path != ""
+	if !explicit {
+		if f.defaultPath == "" {
+			return nil, nil
+		}
+		path = f.defaultPath
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !explicit {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var budget entities.Budget
+	if err := json.Unmarshal(data, &budget); err != nil {
+		return nil, fmt.Errorf("invalid budget file %s: %w", path, err)
+	}
+	if err := budget.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid budget file %s: %w", path, err)
+	}
+	return &budget, nil
+}
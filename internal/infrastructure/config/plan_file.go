@@ -0,0 +1,57 @@
+package config
+
+import (
+	"ccusage-rainbow/internal/domain/entities"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// PlanFile implements the PlanProvider interface with a JSON config file
+type PlanFile struct {
+	defaultPath string
+}
+
+// NewPlanFile creates a new plan file provider defaulting to $XDG_CONFIG_HOME/ccusage-rainbow/plan.json
+// (or the platform equivalent)
+func NewPlanFile() *PlanFile {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		// No config directory, a plan must be given explicitly
+		return &PlanFile{}
+	}
+	return &PlanFile{
+		defaultPath: filepath.Join(base, "ccusage-rainbow", "plan.json"),
+	}
+}
+
+// LoadPlan reads the plan from a config file, or from the default location when path is empty.
+// It returns nil without an error when no path is given and no default file exists.
+func (f *PlanFile) LoadPlan(path string) (*entities.Plan, error) {
+	explicit := path != ""
+	if !explicit {
+		if f.defaultPath == "" {
+			return nil, nil
+		}
+		path = f.defaultPath
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !explicit {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var plan entities.Plan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("invalid plan file %s: %w", path, err)
+	}
+	if err := plan.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid plan file %s: %w", path, err)
+	}
+	return &plan, nil
+}
@@ -0,0 +1,321 @@
+package cost
+
+import (
+	"bytes"
+	"ccusage-rainbow/internal/domain/entities"
+	"ccusage-rainbow/internal/domain/interfaces"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// InputService implements the CostService interface by reading saved ccusage JSON reports
+// from files or standard input. Reports from several files are merged.
+type InputService struct {
+	stdin     io.Reader
+	paths     []string
+	dateRange entities.DateRange
+
+	stdinOnce sync.Once
+	stdinData []byte
+	stdinErr  error
+}
+
+// NewInputService creates a new input cost service; stdin is read when the path is "-"
+func NewInputService(stdin io.Reader) *InputService {
+	return &InputService{
+		stdin: stdin,
+	}
+}
+
+// Configure sets the files to read, "-" for standard input, and limits monthly reports to the
+// date range of the options
+func (s *InputService) Configure(options interfaces.CostSourceOptions) {
+	s.paths = options.InputPaths
+	s.dateRange = options.DateRange
+}
+
+// FetchCostData reads the daily reports, limited to dateRange, and merges them
+func (s *InputService) FetchCostData(ctx context.Context, dateRange entities.DateRange) (*entities.CostResponse, error) {
+	sources, err := s.FetchCostDataBySource(ctx, dateRange)
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) == 1 {
+		// Keep the reported totals of a single report as they are
+		return sources[0].Response, nil
+	}
+
+	responses := make([]*entities.CostResponse, len(sources))
+	for i, source := range sources {
+		responses[i] = source.Response
+	}
+	return entities.MergeCostResponses(responses...), nil
+}
+
+// FetchCostDataBySource reads the daily report of every input separately, limited to dateRange
+func (s *InputService) FetchCostDataBySource(ctx context.Context, dateRange entities.DateRange) ([]entities.SourceCostResponse, error) {
+	if len(s.paths) == 0 {
+		return nil, errors.New("no input file set")
+	}
+
+	var sources []entities.SourceCostResponse
+	for _, path := range s.paths {
+		report, err := s.readReport(path)
+		if err != nil {
+			return nil, err
+		}
+		costResponse, err := s.decodeDaily(path, report)
+		if err != nil {
+			return nil, err
+		}
+		if !dateRange.IsEmpty() {
+			costResponse = costResponse.FilterByDateRange(dateRange)
+		}
+		sources = append(sources, entities.SourceCostResponse{Source: inputName(path), Response: costResponse})
+	}
+	return sources, nil
+}
+
+// FetchMonthlyData reads the monthly reports, or derives them from daily reports, and merges them.
+// Daily reports are limited to the date range before they are rolled up, saved monthly reports
+// keep every month that overlaps it in full.
+func (s *InputService) FetchMonthlyData(ctx context.Context) (*entities.MonthlyResponse, error) {
+	var responses []*entities.MonthlyResponse
+	for _, path := range s.paths {
+		report, err := s.readReport(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := report["monthly"]; ok {
+			monthlyResponse := &entities.MonthlyResponse{}
+			if err := s.decode(path, report, monthlyResponse); err != nil {
+				return nil, err
+			}
+			if !s.dateRange.IsEmpty() {
+				monthlyResponse = monthlyResponse.FilterByDateRange(s.dateRange)
+			}
+			responses = append(responses, monthlyResponse)
+			continue
+		}
+
+		costResponse, err := s.decodeDaily(path, report)
+		if err != nil {
+			return nil, err
+		}
+		if !s.dateRange.IsEmpty() {
+			costResponse = costResponse.FilterByDateRange(s.dateRange)
+		}
+		monthlyResponse, err := monthlyFromDaily(path, costResponse)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, monthlyResponse)
+	}
+
+	if len(responses) == 1 {
+		return responses[0], nil
+	}
+	return entities.MergeMonthlyResponses(responses...), nil
+}
+
+// FetchSessionData reads the session reports and merges them
+func (s *InputService) FetchSessionData(ctx context.Context) (*entities.SessionResponse, error) {
+	var responses []*entities.SessionResponse
+	for _, path := range s.paths {
+		report, err := s.readReport(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.require(path, report, "sessions", "session"); err != nil {
+			return nil, err
+		}
+
+		var sessionResponse entities.SessionResponse
+		if err := s.decode(path, report, &sessionResponse); err != nil {
+			return nil, err
+		}
+		responses = append(responses, &sessionResponse)
+	}
+	return entities.MergeSessionResponses(responses...), nil
+}
+
+// FetchBlocksData reads the blocks reports and merges them
+func (s *InputService) FetchBlocksData(ctx context.Context) (*entities.BlocksResponse, error) {
+	var responses []*entities.BlocksResponse
+	for _, path := range s.paths {
+		report, err := s.readReport(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.require(path, report, "blocks", "blocks"); err != nil {
+			return nil, err
+		}
+
+		var blocksResponse entities.BlocksResponse
+		if err := s.decode(path, report, &blocksResponse); err != nil {
+			return nil, err
+		}
+		responses = append(responses, &blocksResponse)
+	}
+	return entities.MergeBlocksResponses(responses...), nil
+}
+
+// readReport reads an input and splits it into its top-level fields
+func (s *InputService) readReport(path string) (map[string]json.RawMessage, error) {
+	data, err := s.readInput(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var report map[string]json.RawMessage
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, inputError(path, describeJSONError(data, err))
+	}
+	if report == nil {
+		return nil, inputError(path, errors.New("expected a JSON object at the top level, got null"))
+	}
+	return report, nil
+}
+
+// readInput returns the raw input; standard input is read once and reused on refresh
+func (s *InputService) readInput(path string) ([]byte, error) {
+	if path != "-" {
+		return os.ReadFile(path)
+	}
+	s.stdinOnce.Do(func() {
+		s.stdinData, s.stdinErr = io.ReadAll(s.stdin)
+	})
+	return s.stdinData, s.stdinErr
+}
+
+// decodeDaily decodes and validates a daily report
+func (s *InputService) decodeDaily(path string, report map[string]json.RawMessage) (*entities.CostResponse, error) {
+	if err := s.require(path, report, "daily", "daily"); err != nil {
+		return nil, err
+	}
+
+	var costResponse entities.CostResponse
+	if err := s.decode(path, report, &costResponse); err != nil {
+		return nil, err
+	}
+
+	for i, day := range costResponse.Daily {
+		if _, err := time.Parse("2006-01-02", day.Date); err != nil {
+			return nil, inputError(path, fmt.Errorf("daily[%d].date: expected YYYY-MM-DD, got %q", i, day.Date))
+		}
+	}
+	// Hand-assembled reports may leave out the totals
+	if _, ok := report["totals"]; !ok {
+		costResponse.RecalculateTotals()
+	}
+
+	return &costResponse, nil
+}
+
+// decode decodes the report into target, describing type mismatches by field
+func (s *InputService) decode(path string, report map[string]json.RawMessage, target any) error {
+	// Re-marshalling a map of raw messages cannot fail
+	data, _ := json.Marshal(report)
+	if err := json.Unmarshal(data, target); err != nil {
+		return inputError(path, describeJSONError(data, err))
+	}
+	return nil
+}
+
+// require checks that the report has the top-level field of the expected ccusage report
+func (s *InputService) require(path string, report map[string]json.RawMessage, field, reportName string) error {
+	if _, ok := report[field]; ok {
+		return nil
+	}
+
+	fields := make([]string, 0, len(report))
+	for name := range report {
+		fields = append(fields, fmt.Sprintf("%q", name))
+	}
+	sort.Strings(fields)
+	return inputError(path, fmt.Errorf("missing required field %q of a ccusage %s report (found %v)", field, reportName, fields))
+}
+
+// inputError wraps a problem with an input in a CostFetchError
+func inputError(path string, err error) error {
+	name := path
+	if name == "-" {
+		name = "stdin"
+	}
+	return &entities.CostFetchError{
+		Kind:    entities.CostFetchInvalidInput,
+		Command: name,
+		Err:     err,
+	}
+}
+
+// inputName returns the name an input is reported under in per-source views
+func inputName(path string) string {
+	if path == "-" {
+		return "stdin"
+	}
+	return path
+}
+
+// describeJSONError turns encoding/json errors into messages that point at the problem
+func describeJSONError(data []byte, err error) error {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		line, column := position(data, syntaxErr.Offset)
+		return fmt.Errorf("line %d, column %d: %v", line, column, syntaxErr)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		if typeErr.Field == "" {
+			return fmt.Errorf("expected %s, got %s", typeErr.Type, typeErr.Value)
+		}
+		return fmt.Errorf("field %q: expected %s, got %s", typeErr.Field, typeErr.Type, typeErr.Value)
+	}
+
+	return err
+}
+
+// position converts a byte offset into a 1-based line and column
+func position(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := int(offset) - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
+
+// monthlyFromDaily rolls a daily report up into a monthly report
+func monthlyFromDaily(path string, costResponse *entities.CostResponse) (*entities.MonthlyResponse, error) {
+	// Dates carry no time of day, so the timezone does not change the months
+	periods, err := costResponse.MonthlyRollup(time.UTC)
+	if err != nil {
+		return nil, inputError(path, err)
+	}
+
+	monthlyResponse := &entities.MonthlyResponse{Monthly: []entities.MonthlyUsage{}, Totals: costResponse.Totals}
+	for _, period := range periods {
+		monthlyResponse.Monthly = append(monthlyResponse.Monthly, entities.MonthlyUsage{
+			Month:               period.Period,
+			InputTokens:         period.InputTokens,
+			OutputTokens:        period.OutputTokens,
+			CacheCreationTokens: period.CacheCreationTokens,
+			CacheReadTokens:     period.CacheReadTokens,
+			TotalTokens:         period.TotalTokens,
+			TotalCost:           period.TotalCost,
+			ModelsUsed:          period.ModelsUsed,
+			ModelBreakdowns:     period.ModelBreakdowns,
+		})
+	}
+	return monthlyResponse, nil
+}
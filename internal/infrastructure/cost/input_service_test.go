@@ -0,0 +1,260 @@
+package cost
+
+import (
+	"ccusage-rainbow/internal/domain/entities"
+	"ccusage-rainbow/internal/domain/interfaces"
+	"context"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const dailyReportA = `{
+  "daily": [
+    {"date": "2026-09-30", "inputTokens": 100, "totalTokens": 100, "totalCost": 1.5,
+     "modelsUsed": ["claude-sonnet-4-20250514"],
+     "modelBreakdowns": [{"modelName": "claude-sonnet-4-20250514", "inputTokens": 100, "cost": 1.5}]},
+    {"date": "2026-10-01", "inputTokens": 200, "totalTokens": 200, "totalCost": 2,
+     "modelsUsed": ["claude-sonnet-4-20250514"],
+     "modelBreakdowns": [{"modelName": "claude-sonnet-4-20250514", "inputTokens": 200, "cost": 2}]}
+  ],
+  "totals": {"inputTokens": 300, "totalTokens": 300, "totalCost": 3.5}
+}`
+
+const dailyReportB = `{
+  "daily": [
+    {"date": "2026-10-01", "inputTokens": 50, "totalTokens": 50, "totalCost": 4,
+     "modelsUsed": ["claude-opus-4-20250514"],
+     "modelBreakdowns": [{"modelName": "claude-opus-4-20250514", "inputTokens": 50, "cost": 4}]},
+    {"date": "2026-10-02", "inputTokens": 10, "totalTokens": 10, "totalCost": 0.5}
+  ]
+}`
+
+const monthlyReport = `{
+  "monthly": [
+    {"month": "2026-08", "totalTokens": 10, "totalCost": 8},
+    {"month": "2026-09", "totalTokens": 20, "totalCost": 9},
+    {"month": "2026-10", "totalTokens": 30, "totalCost": 10}
+  ],
+  "totals": {"totalTokens": 60, "totalCost": 27}
+}`
+
+// writeInput writes an input file into a temporary directory and returns its path
+func writeInput(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// inputService returns an input service reading paths, with stdin as standard input
+func inputService(stdin string, dateRange entities.DateRange, paths ...string) *InputService {
+	service := NewInputService(strings.NewReader(stdin))
+	service.Configure(interfaces.CostSourceOptions{Source: interfaces.CostSourceInput, DateRange: dateRange, InputPaths: paths})
+	return service
+}
+
+func assertAmount(t *testing.T, name string, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("%s: got %v, want %v", name, got, want)
+	}
+}
+
+func TestInputServiceSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		fetch   func(*InputService) error
+		want    string
+	}{
+		{"syntax error", "{\n  \"daily\": [\n    {\"date\": \"2026-10-01\",}\n  ]\n}", fetchDaily, "line 3, column "},
+		{"null", "null", fetchDaily, "expected a JSON object at the top level, got null"},
+		{"top-level array", "[]", fetchDaily, "got array"},
+		{"missing daily", `{"sessions": [], "totals": {}}`, fetchDaily, `missing required field "daily" of a ccusage daily report (found ["sessions" "totals"])`},
+		{"wrong type", `{"daily": [{"date": "2026-10-01", "totalCost": "3"}]}`, fetchDaily, `totalCost": expected float64, got string`},
+		{"bad date", `{"daily": [{"date": "2026-10-01"}, {"date": "10/02/2026"}]}`, fetchDaily, `daily[1].date: expected YYYY-MM-DD, got "10/02/2026"`},
+		{"missing sessions", dailyReportA, fetchSessions, `missing required field "sessions" of a ccusage session report`},
+		{"missing blocks", dailyReportA, fetchBlocks, `missing required field "blocks" of a ccusage blocks report`},
+	}
+	for _, tt := range tests {
+		path := writeInput(t, "report.json", tt.content)
+		err := tt.fetch(inputService("", entities.DateRange{}, path))
+
+		var fetchErr *entities.CostFetchError
+		if !errors.As(err, &fetchErr) || fetchErr.Kind != entities.CostFetchInvalidInput {
+			t.Errorf("%s: got error %v, want an invalid input error", tt.name, err)
+			continue
+		}
+		if fetchErr.Command != path {
+			t.Errorf("%s: got input %q, want %q", tt.name, fetchErr.Command, path)
+		}
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: got error %q, want one containing %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func fetchDaily(service *InputService) error {
+	_, err := service.FetchCostData(context.Background(), entities.DateRange{})
+	return err
+}
+
+func fetchSessions(service *InputService) error {
+	_, err := service.FetchSessionData(context.Background())
+	return err
+}
+
+func fetchBlocks(service *InputService) error {
+	_, err := service.FetchBlocksData(context.Background())
+	return err
+}
+
+func TestInputServiceMissingFile(t *testing.T) {
+	service := inputService("", entities.DateRange{}, filepath.Join(t.TempDir(), "missing.json"))
+	if _, err := service.FetchCostData(context.Background(), entities.DateRange{}); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("got error %v, want a missing file", err)
+	}
+
+	if _, err := inputService("", entities.DateRange{}).FetchCostData(context.Background(), entities.DateRange{}); err == nil {
+		t.Error("got no error without input files")
+	}
+}
+
+func TestInputServiceMerge(t *testing.T) {
+	pathA := writeInput(t, "a.json", dailyReportA)
+	pathB := writeInput(t, "b.json", dailyReportB)
+	service := inputService("", entities.DateRange{}, pathA, pathB)
+
+	merged, err := service.FetchCostData(context.Background(), entities.DateRange{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantDays := []struct {
+		date   string
+		cost   float64
+		models int
+	}{
+		{"2026-09-30", 1.5, 1},
+		// The same date from both inputs is summed, its models combined
+		{"2026-10-01", 6, 2},
+		{"2026-10-02", 0.5, 0},
+	}
+	if len(merged.Daily) != len(wantDays) {
+		t.Fatalf("got %d days, want %d", len(merged.Daily), len(wantDays))
+	}
+	for i, want := range wantDays {
+		day := merged.Daily[i]
+		if day.Date != want.date || len(day.ModelBreakdowns) != want.models {
+			t.Errorf("day %d: got %s with %d models, want %s with %d", i, day.Date, len(day.ModelBreakdowns), want.date, want.models)
+		}
+		assertAmount(t, want.date, day.TotalCost, want.cost)
+	}
+	// Totals are recomputed, the second input has none
+	assertAmount(t, "total cost", merged.Totals.TotalCost, 8)
+	if merged.Totals.InputTokens != 360 {
+		t.Errorf("got %d input tokens, want 360", merged.Totals.InputTokens)
+	}
+
+	sources, err := service.FetchCostDataBySource(context.Background(), entities.DateRange{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sources) != 2 || sources[0].Source != pathA || sources[1].Source != pathB {
+		t.Fatalf("got sources %+v, want %s and %s", sources, pathA, pathB)
+	}
+	assertAmount(t, "first source", sources[0].Response.Totals.TotalCost, 3.5)
+	// Hand-assembled reports without totals get them computed
+	assertAmount(t, "second source", sources[1].Response.Totals.TotalCost, 4.5)
+}
+
+func TestInputServiceSingleReportKeepsTotals(t *testing.T) {
+	// Totals that disagree with the days, e.g. from a report cut by hand
+	path := writeInput(t, "report.json", strings.Replace(dailyReportA, `"totalCost": 3.5`, `"totalCost": 99`, 1))
+
+	costData, err := inputService("", entities.DateRange{}, path).FetchCostData(context.Background(), entities.DateRange{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertAmount(t, "total cost", costData.Totals.TotalCost, 99)
+}
+
+func TestInputServiceStdin(t *testing.T) {
+	path := writeInput(t, "b.json", dailyReportB)
+	service := inputService(dailyReportA, entities.DateRange{}, "-", path)
+
+	// Standard input is read once and reused on every refresh
+	for range 2 {
+		costData, err := service.FetchCostData(context.Background(), entities.DateRange{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertAmount(t, "total cost", costData.Totals.TotalCost, 8)
+	}
+
+	sources, err := service.FetchCostDataBySource(context.Background(), entities.DateRange{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sources) != 2 || sources[0].Source != "stdin" {
+		t.Errorf("got sources %+v, want stdin first", sources)
+	}
+
+	_, err = inputService("{", entities.DateRange{}, "-").FetchCostData(context.Background(), entities.DateRange{})
+	var fetchErr *entities.CostFetchError
+	if !errors.As(err, &fetchErr) || fetchErr.Command != "stdin" {
+		t.Errorf("got error %v, want an invalid input error naming stdin", err)
+	}
+}
+
+func TestInputServiceDateRange(t *testing.T) {
+	dailyPath := writeInput(t, "daily.json", dailyReportA)
+	monthlyPath := writeInput(t, "monthly.json", monthlyReport)
+	october := entities.DateRange{Since: "2026-10-01", Until: "2026-10-31"}
+
+	costData, err := inputService("", entities.DateRange{}, dailyPath).FetchCostData(context.Background(), october)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(costData.Daily) != 1 || costData.Daily[0].Date != "2026-10-01" {
+		t.Errorf("got days %+v, want only 2026-10-01", costData.Daily)
+	}
+	assertAmount(t, "daily total", costData.Totals.TotalCost, 2)
+
+	tests := []struct {
+		name      string
+		path      string
+		dateRange entities.DateRange
+		months    []string
+		total     float64
+	}{
+		{"monthly report, whole range", monthlyPath, entities.DateRange{}, []string{"2026-08", "2026-09", "2026-10"}, 27},
+		// Saved months overlapping the range are kept whole
+		{"monthly report", monthlyPath, entities.DateRange{Since: "2026-09-15", Until: "2026-10-05"}, []string{"2026-09", "2026-10"}, 19},
+		{"monthly report, since only", monthlyPath, entities.DateRange{Since: "2026-09-30"}, []string{"2026-09", "2026-10"}, 19},
+		{"monthly report, until only", monthlyPath, entities.DateRange{Until: "2026-08-01"}, []string{"2026-08"}, 8},
+		// Months derived from a daily report only count the days within the range
+		{"daily report", dailyPath, entities.DateRange{Since: "2026-09-15", Until: "2026-10-05"}, []string{"2026-09", "2026-10"}, 3.5},
+		{"daily report, October", dailyPath, october, []string{"2026-10"}, 2},
+	}
+	for _, tt := range tests {
+		monthlyData, err := inputService("", tt.dateRange, tt.path).FetchMonthlyData(context.Background())
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		var months []string
+		for _, month := range monthlyData.Monthly {
+			months = append(months, month.Month)
+		}
+		if strings.Join(months, ",") != strings.Join(tt.months, ",") {
+			t.Errorf("%s: got months %v, want %v", tt.name, months, tt.months)
+		}
+		assertAmount(t, tt.name+" total", monthlyData.Totals.TotalCost, tt.total)
+	}
+}
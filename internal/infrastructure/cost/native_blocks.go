@@ -0,0 +1,112 @@
+package cost
+
+import (
+	"ccusage-rainbow/internal/domain/entities"
+	"math"
+	"sort"
+	"time"
+)
+
+// blockDuration is the length of a Claude billing block
+const blockDuration = 5 * time.Hour
+
+// buildBlocks splits time-sorted records into 5-hour billing blocks the same way ccusage does:
+// a block starts at the hour of its first record and a new one starts when either the block
+// has run for five hours or there has been no activity for five hours.
+func buildBlocks(records []usageRecord, now time.Time) []entities.Block {
+	blocks := []entities.Block{}
+	var current []usageRecord
+	var blockStart time.Time
+
+	flush := func() {
+		if len(current) > 0 {
+			blocks = append(blocks, newBlock(blockStart, current, now))
+		}
+	}
+
+	for _, record := range records {
+		if len(current) > 0 {
+			sinceStart := record.Timestamp.Sub(blockStart)
+			sinceLast := record.Timestamp.Sub(current[len(current)-1].Timestamp)
+			if sinceStart <= blockDuration && sinceLast <= blockDuration {
+				current = append(current, record)
+				continue
+			}
+			flush()
+			if gap, ok := newGapBlock(current[len(current)-1].Timestamp, record.Timestamp); ok {
+				blocks = append(blocks, gap)
+			}
+		}
+		blockStart = record.Timestamp.UTC().Truncate(time.Hour)
+		current = []usageRecord{record}
+	}
+	flush()
+
+	return blocks
+}
+
+// newBlock creates a billing block from its records
+func newBlock(start time.Time, records []usageRecord, now time.Time) entities.Block {
+	first := records[0].Timestamp
+	last := records[len(records)-1].Timestamp
+	end := start.Add(blockDuration)
+
+	block := entities.Block{
+		ID:            start.Format(time.RFC3339),
+		StartTime:     start,
+		EndTime:       end,
+		ActualEndTime: &last,
+		IsActive:      now.Sub(last) < blockDuration && now.Before(end),
+		Entries:       len(records),
+		Models:        []string{},
+	}
+
+	models := make(map[string]bool)
+	for _, record := range records {
+		block.TokenCounts.InputTokens += record.Tokens.InputTokens
+		block.TokenCounts.OutputTokens += record.Tokens.OutputTokens
+		block.TokenCounts.CacheCreationInputTokens += record.Tokens.CacheCreationTokens
+		block.TokenCounts.CacheReadInputTokens += record.Tokens.CacheReadTokens
+		block.CostUSD += record.Tokens.Cost
+		if !models[record.Model] {
+			models[record.Model] = true
+			block.Models = append(block.Models, record.Model)
+		}
+	}
+	sort.Strings(block.Models)
+	// ccusage counts only input and output tokens towards a block's total
+	block.TotalTokens = block.TokenCounts.InputTokens + block.TokenCounts.OutputTokens
+
+	if block.IsActive {
+		minutes := last.Sub(first).Minutes()
+		if minutes > 0 {
+			block.BurnRate = &entities.BurnRate{
+				TokensPerMinute: float64(block.TotalTokens) / minutes,
+				CostPerHour:     block.CostUSD / minutes * 60,
+			}
+			remaining := math.Max(0, end.Sub(now).Minutes())
+			block.Projection = &entities.BlockProjection{
+				TotalTokens:      block.TotalTokens + int(math.Round(block.BurnRate.TokensPerMinute*remaining)),
+				TotalCost:        block.CostUSD + block.BurnRate.CostPerHour/60*remaining,
+				RemainingMinutes: int(math.Round(remaining)),
+			}
+		}
+	}
+
+	return block
+}
+
+// newGapBlock creates the idle period between two blocks, if it is long enough to matter
+func newGapBlock(lastActivity, nextActivity time.Time) (entities.Block, bool) {
+	gapStart := lastActivity.Add(blockDuration)
+	if !gapStart.Before(nextActivity) {
+		return entities.Block{}, false
+	}
+	return entities.Block{
+		ID:        "gap-" + gapStart.UTC().Format(time.RFC3339),
+		StartTime: gapStart.UTC(),
+		EndTime:   nextActivity.UTC(),
+		IsGap:     true,
+		Models:    []string{},
+	}, true
+}
@@ -0,0 +1,466 @@
+package cost
+
+import (
+	"bufio"
+	"ccusage-rainbow/internal/domain/entities"
+	"ccusage-rainbow/internal/domain/interfaces"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// NativeService implements the CostService interface by reading Claude Code JSONL logs directly
+type NativeService struct {
+	pricingProvider interfaces.PricingProvider
+	location        *time.Location
+	now             func() time.Time
+	dateRange       entities.DateRange
+	claudeDirs      []string
+}
+
+// NewNativeService creates a new native cost service
+func NewNativeService(pricingProvider interfaces.PricingProvider) *NativeService {
+	return &NativeService{
+		pricingProvider: pricingProvider,
+		location:        time.Local,
+		now:             time.Now,
+	}
+}
+
+// Configure sets the Claude config directories to read instead of the defaults, and limits the
+// reports other than the daily one to the date range of the options
+func (s *NativeService) Configure(options interfaces.CostSourceOptions) {
+	s.dateRange = options.DateRange
+	s.claudeDirs = options.ClaudeDirs
+}
+
+// usageEntry represents the fields of a Claude Code JSONL log line used for cost calculation
+type usageEntry struct {
+	Timestamp string   `json:"timestamp"`
+	RequestID string   `json:"requestId"`
+	CostUSD   *float64 `json:"costUSD"`
+	Message   struct {
+		ID    string `json:"id"`
+		Model string `json:"model"`
+		Usage *struct {
+			InputTokens              int `json:"input_tokens"`
+			OutputTokens             int `json:"output_tokens"`
+			CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+			CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+		} `json:"usage"`
+	} `json:"message"`
+}
+
+// usageRecord represents a single priced API call read from the logs
+type usageRecord struct {
+	Timestamp   time.Time
+	SessionID   string
+	ProjectPath string
+	Model       string
+	Tokens      entities.ModelBreakdown
+}
+
+// usageGroup accumulates records that share a report key (a day, a month or a session)
+type usageGroup struct {
+	key    string
+	last   time.Time
+	totals entities.ModelBreakdown
+	models map[string]*entities.ModelBreakdown
+}
+
+// FetchCostData reads the JSONL logs under the Claude data directories and aggregates the usage
+// within dateRange per day
+func (s *NativeService) FetchCostData(ctx context.Context, dateRange entities.DateRange) (*entities.CostResponse, error) {
+	dirs, err := s.projectDirs()
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.loadRecords(ctx, dirs, dateRange)
+	if err != nil {
+		return nil, err
+	}
+	return s.dailyResponse(records), nil
+}
+
+// FetchCostDataBySource reads the logs of every Claude data directory separately
+func (s *NativeService) FetchCostDataBySource(ctx context.Context, dateRange entities.DateRange) ([]entities.SourceCostResponse, error) {
+	dirs, err := s.projectDirs()
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []entities.SourceCostResponse
+	for _, dir := range dirs {
+		records, err := s.loadRecords(ctx, []string{dir}, dateRange)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, entities.SourceCostResponse{Source: dir, Response: s.dailyResponse(records)})
+	}
+	return sources, nil
+}
+
+// dailyResponse aggregates records per day
+func (s *NativeService) dailyResponse(records []usageRecord) *entities.CostResponse {
+	response := &entities.CostResponse{Daily: []entities.DailyUsage{}}
+	for _, group := range groupRecords(records, func(r *usageRecord) string {
+		return r.Timestamp.In(s.location).Format("2006-01-02")
+	}) {
+		modelsUsed, breakdowns := group.breakdowns()
+		response.Daily = append(response.Daily, entities.DailyUsage{
+			Date:                group.key,
+			InputTokens:         group.totals.InputTokens,
+			OutputTokens:        group.totals.OutputTokens,
+			CacheCreationTokens: group.totals.CacheCreationTokens,
+			CacheReadTokens:     group.totals.CacheReadTokens,
+			TotalTokens:         totalTokens(&group.totals),
+			TotalCost:           group.totals.Cost,
+			ModelsUsed:          modelsUsed,
+			ModelBreakdowns:     breakdowns,
+		})
+		addTotals(&response.Totals, &group.totals)
+	}
+
+	return response
+}
+
+// FetchMonthlyData reads all JSONL logs and aggregates them per month
+func (s *NativeService) FetchMonthlyData(ctx context.Context) (*entities.MonthlyResponse, error) {
+	dirs, err := s.projectDirs()
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.loadRecords(ctx, dirs, s.dateRange)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &entities.MonthlyResponse{Monthly: []entities.MonthlyUsage{}}
+	for _, group := range groupRecords(records, func(r *usageRecord) string {
+		return r.Timestamp.In(s.location).Format("2006-01")
+	}) {
+		modelsUsed, breakdowns := group.breakdowns()
+		response.Monthly = append(response.Monthly, entities.MonthlyUsage{
+			Month:               group.key,
+			InputTokens:         group.totals.InputTokens,
+			OutputTokens:        group.totals.OutputTokens,
+			CacheCreationTokens: group.totals.CacheCreationTokens,
+			CacheReadTokens:     group.totals.CacheReadTokens,
+			TotalTokens:         totalTokens(&group.totals),
+			TotalCost:           group.totals.Cost,
+			ModelsUsed:          modelsUsed,
+			ModelBreakdowns:     breakdowns,
+		})
+		addTotals(&response.Totals, &group.totals)
+	}
+
+	return response, nil
+}
+
+// FetchSessionData reads all JSONL logs and aggregates them per session
+func (s *NativeService) FetchSessionData(ctx context.Context) (*entities.SessionResponse, error) {
+	dirs, err := s.projectDirs()
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.loadRecords(ctx, dirs, s.dateRange)
+	if err != nil {
+		return nil, err
+	}
+
+	projects := make(map[string]string)
+	for i := range records {
+		projects[records[i].SessionID] = records[i].ProjectPath
+	}
+
+	response := &entities.SessionResponse{Sessions: []entities.SessionUsage{}}
+	for _, group := range groupRecords(records, func(r *usageRecord) string {
+		return r.SessionID
+	}) {
+		modelsUsed, breakdowns := group.breakdowns()
+		response.Sessions = append(response.Sessions, entities.SessionUsage{
+			SessionID:           group.key,
+			ProjectPath:         projects[group.key],
+			LastActivity:        group.last.In(s.location).Format("2006-01-02"),
+			InputTokens:         group.totals.InputTokens,
+			OutputTokens:        group.totals.OutputTokens,
+			CacheCreationTokens: group.totals.CacheCreationTokens,
+			CacheReadTokens:     group.totals.CacheReadTokens,
+			TotalTokens:         totalTokens(&group.totals),
+			TotalCost:           group.totals.Cost,
+			ModelsUsed:          modelsUsed,
+			ModelBreakdowns:     breakdowns,
+		})
+		addTotals(&response.Totals, &group.totals)
+	}
+
+	// Most expensive sessions first, like ccusage
+	sort.SliceStable(response.Sessions, func(i, j int) bool {
+		return response.Sessions[i].TotalCost > response.Sessions[j].TotalCost
+	})
+
+	return response, nil
+}
+
+// FetchBlocksData reads all JSONL logs and splits them into 5-hour billing blocks
+func (s *NativeService) FetchBlocksData(ctx context.Context) (*entities.BlocksResponse, error) {
+	dirs, err := s.projectDirs()
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.loadRecords(ctx, dirs, s.dateRange)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.BlocksResponse{Blocks: buildBlocks(records, s.now())}, nil
+}
+
+// projectDirs returns the project log directories to read: those of the configured Claude
+// directories, or the default locations when none are configured
+func (s *NativeService) projectDirs() ([]string, error) {
+	if len(s.claudeDirs) == 0 {
+		dirs := claudeProjectDirs()
+		if len(dirs) == 0 {
+			return nil, errors.New("no Claude data directories found (checked $CLAUDE_CONFIG_DIR, ~/.config/claude and ~/.claude)")
+		}
+		return dirs, nil
+	}
+
+	var dirs []string
+	for _, dir := range s.claudeDirs {
+		// Accept both a Claude config directory and its projects directory
+		projects := filepath.Join(dir, "projects")
+		if info, err := os.Stat(projects); err == nil && info.IsDir() {
+			dirs = append(dirs, projects)
+		} else if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			dirs = append(dirs, dir)
+		} else {
+			return nil, fmt.Errorf("Claude data directory %s not found", dir)
+		}
+	}
+	return dirs, nil
+}
+
+// loadRecords reads every usage record within the date range under the given project directories,
+// dropping duplicates
+func (s *NativeService) loadRecords(ctx context.Context, dirs []string, dateRange entities.DateRange) ([]usageRecord, error) {
+	var records []usageRecord
+	seen := make(map[string]bool)
+
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if d.IsDir() || filepath.Ext(path) != ".jsonl" {
+				return nil
+			}
+
+			// Logs live at <projects>/<project>/<session>.jsonl
+			sessionID := strings.TrimSuffix(filepath.Base(path), ".jsonl")
+			projectPath, _ := filepath.Rel(dir, filepath.Dir(path))
+
+			return s.readFile(path, func(entry *usageEntry) {
+				// The same message is logged again when a session is resumed
+				if entry.Message.ID != "" && entry.RequestID != "" {
+					key := entry.Message.ID + ":" + entry.RequestID
+					if seen[key] {
+						return
+					}
+					seen[key] = true
+				}
+				if record, ok := s.toRecord(entry); ok && dateRange.Contains(record.Timestamp.In(s.location).Format("2006-01-02")) {
+					record.SessionID = sessionID
+					record.ProjectPath = projectPath
+					records = append(records, record)
+				}
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Timestamp.Before(records[j].Timestamp)
+	})
+
+	return records, nil
+}
+
+// readFile decodes every usage line of a JSONL file, skipping lines that are not assistant usage records
+func (s *NativeService) readFile(path string, handle func(entry *usageEntry)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	// Lines can be very long (tool results are logged inline), so avoid bufio.Scanner's token limit
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			var entry usageEntry
+			if json.Unmarshal(line, &entry) == nil && entry.Message.Usage != nil && entry.Timestamp != "" {
+				handle(&entry)
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// toRecord converts a log entry into a priced usage record
+func (s *NativeService) toRecord(entry *usageEntry) (usageRecord, bool) {
+	timestamp, err := time.Parse(time.RFC3339Nano, entry.Timestamp)
+	if err != nil {
+		return usageRecord{}, false
+	}
+	model := entry.Message.Model
+	if model == "" || model == "<synthetic>" {
+		return usageRecord{}, false
+	}
+
+	usage := entry.Message.Usage
+	record := usageRecord{
+		Timestamp: timestamp,
+		Model:     model,
+		Tokens: entities.ModelBreakdown{
+			ModelName:           model,
+			InputTokens:         usage.InputTokens,
+			OutputTokens:        usage.OutputTokens,
+			CacheCreationTokens: usage.CacheCreationInputTokens,
+			CacheReadTokens:     usage.CacheReadInputTokens,
+		},
+	}
+
+	// Older Claude Code versions log the cost, newer ones only log tokens
+	if entry.CostUSD != nil {
+		record.Tokens.Cost = *entry.CostUSD
+	} else if pricing, err := s.pricingProvider.GetModelPricing(model); err == nil {
+		record.Tokens.Cost = pricing.CalculateBreakdownCost(&record.Tokens)
+	}
+
+	return record, true
+}
+
+// groupRecords groups records by key, returning the groups sorted by key
+func groupRecords(records []usageRecord, keyOf func(r *usageRecord) string) []*usageGroup {
+	groups := make(map[string]*usageGroup)
+	for i := range records {
+		record := &records[i]
+		key := keyOf(record)
+		group, ok := groups[key]
+		if !ok {
+			group = &usageGroup{key: key, models: make(map[string]*entities.ModelBreakdown)}
+			groups[key] = group
+		}
+		if record.Timestamp.After(group.last) {
+			group.last = record.Timestamp
+		}
+		addBreakdown(&group.totals, &record.Tokens)
+
+		breakdown, ok := group.models[record.Model]
+		if !ok {
+			breakdown = &entities.ModelBreakdown{ModelName: record.Model}
+			group.models[record.Model] = breakdown
+		}
+		addBreakdown(breakdown, &record.Tokens)
+	}
+
+	result := make([]*usageGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].key < result[j].key
+	})
+	return result
+}
+
+// breakdowns returns the sorted model names and the per-model breakdowns, most expensive first
+func (g *usageGroup) breakdowns() ([]string, []entities.ModelBreakdown) {
+	modelsUsed := make([]string, 0, len(g.models))
+	breakdowns := make([]entities.ModelBreakdown, 0, len(g.models))
+	for name, breakdown := range g.models {
+		modelsUsed = append(modelsUsed, name)
+		breakdowns = append(breakdowns, *breakdown)
+	}
+	sort.Strings(modelsUsed)
+	sort.Slice(breakdowns, func(i, j int) bool {
+		return breakdowns[i].Cost > breakdowns[j].Cost
+	})
+	return modelsUsed, breakdowns
+}
+
+// addBreakdown adds the token counts and cost of src to dst
+func addBreakdown(dst, src *entities.ModelBreakdown) {
+	dst.InputTokens += src.InputTokens
+	dst.OutputTokens += src.OutputTokens
+	dst.CacheCreationTokens += src.CacheCreationTokens
+	dst.CacheReadTokens += src.CacheReadTokens
+	dst.Cost += src.Cost
+}
+
+// addTotals adds the token counts and cost of a group to the report totals
+func addTotals(totals *entities.Totals, group *entities.ModelBreakdown) {
+	totals.InputTokens += group.InputTokens
+	totals.OutputTokens += group.OutputTokens
+	totals.CacheCreationTokens += group.CacheCreationTokens
+	totals.CacheReadTokens += group.CacheReadTokens
+	totals.TotalTokens += totalTokens(group)
+	totals.TotalCost += group.Cost
+}
+
+// totalTokens returns the sum of all token kinds of a breakdown
+func totalTokens(breakdown *entities.ModelBreakdown) int {
+	return breakdown.InputTokens + breakdown.OutputTokens + breakdown.CacheCreationTokens + breakdown.CacheReadTokens
+}
+
+// claudeProjectDirs returns the existing Claude Code project log directories
+func claudeProjectDirs() []string {
+	var candidates []string
+	if configDir := os.Getenv("CLAUDE_CONFIG_DIR"); configDir != "" {
+		// CLAUDE_CONFIG_DIR may hold several comma-separated directories
+		for _, dir := range strings.Split(configDir, ",") {
+			if dir = strings.TrimSpace(dir); dir != "" {
+				candidates = append(candidates, filepath.Join(dir, "projects"))
+			}
+		}
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		candidates = append(candidates,
+			filepath.Join(home, ".config", "claude", "projects"),
+			filepath.Join(home, ".claude", "projects"),
+		)
+	}
+
+	var dirs []string
+	seen := make(map[string]bool)
+	for _, dir := range candidates {
+		if seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package cost
+
+import "os/exec"
+
+// setProcessGroup leaves cmd as it is, cancelling its context kills only the process itself
+func setProcessGroup(cmd *exec.Cmd) {}
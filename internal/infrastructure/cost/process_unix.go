@@ -0,0 +1,18 @@
+//go:build unix
+
+package cost
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup starts cmd in a process group of its own and kills the whole group when its
+// context is cancelled, so ccusage does not outlive the npx process that started it
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		// A negative pid signals the process group
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
@@ -0,0 +1,109 @@
+package cost
+
+import (
+	"ccusage-rainbow/internal/domain/entities"
+	"ccusage-rainbow/internal/domain/interfaces"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// Selector implements the CostService and CostSourceSelector interfaces by delegating to the selected source
+type Selector struct {
+	npxService    interfaces.ConfigurableCostService
+	nativeService interfaces.ConfigurableCostService
+	inputService  interfaces.ConfigurableCostService
+	source        string
+	timeout       time.Duration
+}
+
+// NewSelector creates a new cost source selector defaulting to the auto source
+func NewSelector(npxService, nativeService, inputService interfaces.ConfigurableCostService) *Selector {
+	return &Selector{
+		npxService:    npxService,
+		nativeService: nativeService,
+		inputService:  inputService,
+		source:        interfaces.CostSourceAuto,
+	}
+}
+
+// SelectSource configures which cost source subsequent fetches use
+func (s *Selector) SelectSource(options interfaces.CostSourceOptions) error {
+	switch options.Source {
+	case interfaces.CostSourceAuto, interfaces.CostSourceNpx, interfaces.CostSourceNative:
+		s.source = options.Source
+		s.timeout = options.Timeout
+		s.npxService.Configure(options)
+		s.nativeService.Configure(options)
+		s.inputService.Configure(options)
+		// Claude directories only make sense for the native source
+		if len(options.ClaudeDirs) > 0 && s.source == interfaces.CostSourceAuto {
+			s.source = interfaces.CostSourceNative
+		}
+		// Input files replace whichever source was chosen
+		if len(options.InputPaths) > 0 {
+			s.source = interfaces.CostSourceInput
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown cost source %q (expected %s, %s or %s)",
+			options.Source, interfaces.CostSourceAuto, interfaces.CostSourceNpx, interfaces.CostSourceNative)
+	}
+}
+
+// FetchCostData fetches cost data within dateRange from the selected source
+func (s *Selector) FetchCostData(ctx context.Context, dateRange entities.DateRange) (*entities.CostResponse, error) {
+	return fetchFromSource(ctx, s, func(service interfaces.CostService, ctx context.Context) (*entities.CostResponse, error) {
+		return service.FetchCostData(ctx, dateRange)
+	})
+}
+
+// FetchCostDataBySource fetches cost data within dateRange per configured source from the selected source
+func (s *Selector) FetchCostDataBySource(ctx context.Context, dateRange entities.DateRange) ([]entities.SourceCostResponse, error) {
+	return fetchFromSource(ctx, s, func(service interfaces.CostService, ctx context.Context) ([]entities.SourceCostResponse, error) {
+		return service.FetchCostDataBySource(ctx, dateRange)
+	})
+}
+
+// FetchMonthlyData fetches the monthly report from the selected source
+func (s *Selector) FetchMonthlyData(ctx context.Context) (*entities.MonthlyResponse, error) {
+	return fetchFromSource(ctx, s, interfaces.CostService.FetchMonthlyData)
+}
+
+// FetchSessionData fetches the per-session report from the selected source
+func (s *Selector) FetchSessionData(ctx context.Context) (*entities.SessionResponse, error) {
+	return fetchFromSource(ctx, s, interfaces.CostService.FetchSessionData)
+}
+
+// FetchBlocksData fetches the 5-hour billing block report from the selected source
+func (s *Selector) FetchBlocksData(ctx context.Context) (*entities.BlocksResponse, error) {
+	return fetchFromSource(ctx, s, interfaces.CostService.FetchBlocksData)
+}
+
+// fetchFromSource runs a report fetch against the selected source, applying the timeout
+// and the auto source's fallback to native when npx is missing
+func fetchFromSource[T any](ctx context.Context, s *Selector, fetch func(interfaces.CostService, context.Context) (T, error)) (T, error) {
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	switch s.source {
+	case interfaces.CostSourceNpx:
+		return fetch(s.npxService, ctx)
+	case interfaces.CostSourceNative:
+		return fetch(s.nativeService, ctx)
+	case interfaces.CostSourceInput:
+		return fetch(s.inputService, ctx)
+	default:
+		result, err := fetch(s.npxService, ctx)
+		if errors.Is(err, exec.ErrNotFound) {
+			// Node is not installed, read the logs ourselves
+			return fetch(s.nativeService, ctx)
+		}
+		return result, err
+	}
+}
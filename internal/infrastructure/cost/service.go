@@ -1,33 +1,152 @@
 package cost
 
 import (
+	"bytes"
 	"ccusage-rainbow/internal/domain/entities"
+	"ccusage-rainbow/internal/domain/interfaces"
+	"context"
 	"encoding/json"
+	"errors"
 	"os/exec"
+	"strings"
+	"time"
 )
 
+// processWaitDelay is how long a cancelled command may keep its output open before it is abandoned
+const processWaitDelay = 5 * time.Second
+
 // Service implements the CostService interface
-type Service struct{}
+type Service struct {
+	dateRange entities.DateRange
+}
 
 // NewService creates a new cost service
 func NewService() *Service {
 	return &Service{}
 }
 
-// FetchCostData fetches cost data from ccusage command
-func (s *Service) FetchCostData() (*entities.CostResponse, error) {
-	// Execute the ccusage command with -j flag
-	cmd := exec.Command("npx", "ccusage@latest", "-j")
-	output, err := cmd.Output()
+// Configure limits the monthly, session and block reports to the date range of the options
+func (s *Service) Configure(options interfaces.CostSourceOptions) {
+	s.dateRange = options.DateRange
+}
+
+// FetchCostData fetches the daily cost data within dateRange from ccusage command
+func (s *Service) FetchCostData(ctx context.Context, dateRange entities.DateRange) (*entities.CostResponse, error) {
+	var costResponse entities.CostResponse
+	if err := s.run(ctx, &costResponse, "daily", dateRange); err != nil {
+		return nil, err
+	}
+	return &costResponse, nil
+}
+
+// FetchCostDataBySource fetches cost data from ccusage command as a single source
+func (s *Service) FetchCostDataBySource(ctx context.Context, dateRange entities.DateRange) ([]entities.SourceCostResponse, error) {
+	costResponse, err := s.FetchCostData(ctx, dateRange)
 	if err != nil {
 		return nil, err
 	}
+	return []entities.SourceCostResponse{{Source: "npx", Response: costResponse}}, nil
+}
 
-	// Parse the JSON output
-	var costResponse entities.CostResponse
-	if err := json.Unmarshal(output, &costResponse); err != nil {
+// FetchMonthlyData fetches the monthly report from ccusage command
+func (s *Service) FetchMonthlyData(ctx context.Context) (*entities.MonthlyResponse, error) {
+	var monthlyResponse entities.MonthlyResponse
+	if err := s.run(ctx, &monthlyResponse, "monthly", s.dateRange); err != nil {
 		return nil, err
 	}
+	return &monthlyResponse, nil
+}
 
-	return &costResponse, nil
+// FetchSessionData fetches the per-session report from ccusage command
+func (s *Service) FetchSessionData(ctx context.Context) (*entities.SessionResponse, error) {
+	var sessionResponse entities.SessionResponse
+	if err := s.run(ctx, &sessionResponse, "session", s.dateRange); err != nil {
+		return nil, err
+	}
+	return &sessionResponse, nil
+}
+
+// FetchBlocksData fetches the 5-hour billing block report from ccusage command
+func (s *Service) FetchBlocksData(ctx context.Context) (*entities.BlocksResponse, error) {
+	var blocksResponse entities.BlocksResponse
+	if err := s.run(ctx, &blocksResponse, "blocks", s.dateRange); err != nil {
+		return nil, err
+	}
+	return &blocksResponse, nil
+}
+
+// run executes a ccusage report within a date range with the -j flag and decodes its JSON output into target
+func (s *Service) run(ctx context.Context, target any, report string, dateRange entities.DateRange) error {
+	args := []string{"ccusage@latest", report, "-j"}
+	// ccusage expects YYYYMMDD
+	if dateRange.Since != "" {
+		args = append(args, "--since", strings.ReplaceAll(dateRange.Since, "-", ""))
+	}
+	if dateRange.Until != "" {
+		args = append(args, "--until", strings.ReplaceAll(dateRange.Until, "-", ""))
+	}
+	command := "npx " + strings.Join(args, " ")
+
+	// Kill the command and the processes it started if ctx is cancelled
+	cmd := exec.CommandContext(ctx, "npx", args...)
+	setProcessGroup(cmd)
+	cmd.WaitDelay = processWaitDelay
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	started := time.Now()
+	output, err := cmd.Output()
+	if err != nil {
+		return commandError(ctx, command, err, stderr.String(), time.Since(started))
+	}
+
+	// Parse the JSON output
+	if err := json.Unmarshal(output, target); err != nil {
+		return &entities.CostFetchError{
+			Kind:    entities.CostFetchDecodeFailed,
+			Command: command,
+			Stderr:  strings.TrimSpace(stderr.String()),
+			Err:     err,
+		}
+	}
+
+	return nil
+}
+
+// commandError converts an exec failure into a CostFetchError
+func commandError(ctx context.Context, command string, err error, stderr string, elapsed time.Duration) error {
+	stderr = strings.TrimSpace(stderr)
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &entities.CostFetchError{
+			Kind:    entities.CostFetchTimeout,
+			Command: command,
+			Stderr:  stderr,
+			Timeout: elapsed.Round(time.Second),
+			Err:     ctx.Err(),
+		}
+	}
+	if ctx.Err() != nil {
+		// Cancelled by the caller, nothing to diagnose
+		return ctx.Err()
+	}
+	if errors.Is(err, exec.ErrNotFound) {
+		return &entities.CostFetchError{
+			Kind:    entities.CostFetchCommandNotFound,
+			Command: "npx",
+			Err:     err,
+		}
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return &entities.CostFetchError{
+			Kind:     entities.CostFetchCommandFailed,
+			Command:  command,
+			ExitCode: exitErr.ExitCode(),
+			Stderr:   stderr,
+			Err:      err,
+		}
+	}
+
+	return err
 }
@@ -0,0 +1,56 @@
+package currency
+
+import (
+	"ccusage-rainbow/internal/domain/entities"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// RateFile implements the ExchangeRateProvider interface with a local JSON file, so no rates
+// are ever fetched over the network
+type RateFile struct {
+	defaultPath string
+}
+
+// NewRateFile creates a new rate file provider defaulting to $XDG_CONFIG_HOME/ccusage-rainbow/rates.json
+// (or the platform equivalent)
+func NewRateFile() *RateFile {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		// No config directory, rates must be given explicitly
+		return &RateFile{}
+	}
+	return &RateFile{
+		defaultPath: filepath.Join(base, "ccusage-rainbow", "rates.json"),
+	}
+}
+
+// LoadRates reads exchange rates from a rate file, or from the default location when path is empty
+func (f *RateFile) LoadRates(path string) (*entities.ExchangeRates, error) {
+	if path == "" {
+		if f.defaultPath == "" {
+			return nil, errors.New("no exchange rate file given and no config directory to look in")
+		}
+		path = f.defaultPath
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("exchange rate file %s not found, create it with e.g. {\"base\": \"USD\", \"rates\": {\"EUR\": 0.92}}", path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var rates entities.ExchangeRates
+	if err := json.Unmarshal(data, &rates); err != nil {
+		return nil, fmt.Errorf("invalid exchange rate file %s: %w", path, err)
+	}
+	if err := rates.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid exchange rate file %s: %w", path, err)
+	}
+	return &rates, nil
+}
@@ -0,0 +1,125 @@
+package pricing
+
+import (
+	"ccusage-rainbow/internal/domain/entities"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+//go:embed model_prices.json
+var builtinPrices []byte
+
+// providerPrefixes are stripped from model names before matching (LiteLLM keys some models by provider)
+var providerPrefixes = []string{"anthropic/", "anthropic.", "bedrock/", "vertex_ai/"}
+
+// dateSuffix matches the release date suffix of model IDs such as claude-sonnet-4-20250514
+var dateSuffix = regexp.MustCompile(`-\d{8}$`)
+
+// Table implements the PricingProvider interface with a built-in table and optional overrides
+type Table struct {
+	prices map[string]entities.ModelPricing
+}
+
+// NewTable creates a new pricing table populated from the embedded price list
+func NewTable() *Table {
+	prices, err := parsePrices(builtinPrices)
+	if err != nil {
+		// The embedded file is part of the binary, so this only happens on a broken build
+		panic(fmt.Sprintf("invalid built-in model prices: %v", err))
+	}
+	return &Table{
+		prices: prices,
+	}
+}
+
+// GetModelPricing returns the pricing of a model, matching dated and provider-prefixed names
+func (t *Table) GetModelPricing(modelName string) (*entities.ModelPricing, error) {
+	name := normalizeModelName(modelName)
+	if pricing, ok := t.prices[name]; ok {
+		return &pricing, nil
+	}
+
+	// Fall back to an undated match, e.g. "claude-sonnet-4" or a newer dated release of a known
+	// model. Of several releases the latest wins; dates sort as strings.
+	base := dateSuffix.ReplaceAllString(name, "")
+	match := ""
+	for key := range t.prices {
+		if dateSuffix.ReplaceAllString(key, "") == base && key > match {
+			match = key
+		}
+	}
+	if match != "" {
+		pricing := t.prices[match]
+		return &pricing, nil
+	}
+
+	return nil, fmt.Errorf("no pricing for model %q", modelName)
+}
+
+// LoadOverrides merges prices from a LiteLLM-style JSON file over the built-in table
+func (t *Table) LoadOverrides(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	prices, err := parsePrices(data)
+	if err != nil {
+		return fmt.Errorf("invalid pricing file %s: %w", path, err)
+	}
+	for name, pricing := range prices {
+		t.prices[name] = pricing
+	}
+	return nil
+}
+
+// litellmEntry represents the cost fields of a LiteLLM model_prices_and_context_window.json entry
+type litellmEntry struct {
+	InputCostPerToken         *float64 `json:"input_cost_per_token"`
+	OutputCostPerToken        *float64 `json:"output_cost_per_token"`
+	CacheCreationCostPerToken *float64 `json:"cache_creation_input_token_cost"`
+	CacheReadCostPerToken     *float64 `json:"cache_read_input_token_cost"`
+}
+
+// parsePrices decodes a LiteLLM-style price map, skipping entries without token prices
+func parsePrices(data []byte) (map[string]entities.ModelPricing, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	prices := make(map[string]entities.ModelPricing)
+	for name, message := range raw {
+		var entry litellmEntry
+		// LiteLLM files contain non-model keys such as "sample_spec", ignore anything that does not decode
+		if json.Unmarshal(message, &entry) != nil || entry.InputCostPerToken == nil || entry.OutputCostPerToken == nil {
+			continue
+		}
+
+		pricing := entities.ModelPricing{
+			InputCostPerToken:  *entry.InputCostPerToken,
+			OutputCostPerToken: *entry.OutputCostPerToken,
+		}
+		if entry.CacheCreationCostPerToken != nil {
+			pricing.CacheCreationCostPerToken = *entry.CacheCreationCostPerToken
+		}
+		if entry.CacheReadCostPerToken != nil {
+			pricing.CacheReadCostPerToken = *entry.CacheReadCostPerToken
+		}
+		prices[normalizeModelName(name)] = pricing
+	}
+
+	return prices, nil
+}
+
+// normalizeModelName lowercases a model name and strips any provider prefix
+func normalizeModelName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, prefix := range providerPrefixes {
+		name = strings.TrimPrefix(name, prefix)
+	}
+	return name
+}
@@ -0,0 +1,137 @@
+package pricing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testPrices is a LiteLLM-style price file with two releases of one model, a provider-prefixed
+// key and entries that are not model prices
+const testPrices = `{
+	"sample_spec": {"input_cost_per_token": "price per input token", "output_cost_per_token": 0},
+	"claude-sonnet-4-20250514": {"input_cost_per_token": 3e-06, "output_cost_per_token": 1.5e-05, "cache_read_input_token_cost": 3e-07},
+	"claude-opus-4-20250514": {"input_cost_per_token": 1.5e-05, "output_cost_per_token": 7.5e-05},
+	"claude-opus-4-20250805": {"input_cost_per_token": 2e-05, "output_cost_per_token": 8e-05},
+	"anthropic/claude-3-haiku-20240307": {"input_cost_per_token": 2.5e-07, "output_cost_per_token": 1.25e-06},
+	"claude-embedding": {"input_cost_per_token": 1e-07}
+}`
+
+// newTestTable creates a table with the test prices only
+func newTestTable(t *testing.T) *Table {
+	t.Helper()
+	prices, err := parsePrices([]byte(testPrices))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Table{prices: prices}
+}
+
+func TestGetModelPricing(t *testing.T) {
+	table := newTestTable(t)
+
+	tests := []struct {
+		model string
+		input float64 // Input price per token, 0 when the model has no pricing
+	}{
+		{"claude-sonnet-4-20250514", 3e-06},
+		{" Claude-Sonnet-4-20250514 ", 3e-06},
+		// Undated IDs and unknown releases fall back to the latest known release
+		{"claude-sonnet-4", 3e-06},
+		{"claude-sonnet-4-20991231", 3e-06},
+		{"claude-opus-4", 2e-05},
+		{"claude-opus-4-20250514", 1.5e-05},
+		// Provider prefixes are stripped from names and keys alike
+		{"anthropic/claude-sonnet-4-20250514", 3e-06},
+		{"anthropic.claude-sonnet-4-20250514", 3e-06},
+		{"bedrock/claude-opus-4", 2e-05},
+		{"vertex_ai/claude-sonnet-4", 3e-06},
+		{"claude-3-haiku-20240307", 2.5e-07},
+		{"claude-3-haiku", 2.5e-07},
+		// Neither another model of the family nor entries without token prices match
+		{"claude-sonnet-4-5", 0},
+		{"claude-embedding", 0},
+		{"sample_spec", 0},
+		{"gpt-4o", 0},
+	}
+	for _, tt := range tests {
+		pricing, err := table.GetModelPricing(tt.model)
+		if tt.input == 0 {
+			if err == nil {
+				t.Errorf("%q: got %+v, want an error", tt.model, pricing)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", tt.model, err)
+			continue
+		}
+		if pricing.InputCostPerToken != tt.input {
+			t.Errorf("%q: got input price %v, want %v", tt.model, pricing.InputCostPerToken, tt.input)
+		}
+	}
+
+	// Optional cache prices default to zero
+	pricing, err := table.GetModelPricing("claude-sonnet-4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pricing.CacheReadCostPerToken != 3e-07 || pricing.CacheCreationCostPerToken != 0 {
+		t.Errorf("got cache prices %v/%v, want 0/3e-07", pricing.CacheCreationCostPerToken, pricing.CacheReadCostPerToken)
+	}
+}
+
+func TestBuiltinPrices(t *testing.T) {
+	table := NewTable()
+	for _, model := range []string{"claude-sonnet-4-20250514", "claude-opus-4-20250514", "claude-3-5-haiku-20241022"} {
+		if _, err := table.GetModelPricing(model); err != nil {
+			t.Errorf("%s: %v", model, err)
+		}
+	}
+}
+
+func TestParsePricesMalformed(t *testing.T) {
+	for _, data := range []string{"", "not json", "[1, 2]", `{"claude-sonnet-4": `} {
+		if _, err := parsePrices([]byte(data)); err == nil {
+			t.Errorf("%q: expected an error", data)
+		}
+	}
+}
+
+func TestLoadOverrides(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	// A malformed file is an error and leaves the table as it was
+	table := newTestTable(t)
+	if err := table.LoadOverrides(write("broken.json", `{"claude-sonnet-4-20250514": {"input_cost_per_token": 1`)); err == nil {
+		t.Error("expected an error for a malformed file")
+	}
+	if err := table.LoadOverrides(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+	if pricing, err := table.GetModelPricing("claude-sonnet-4"); err != nil || pricing.InputCostPerToken != 3e-06 {
+		t.Errorf("got %+v, %v after failed overrides, want the original price", pricing, err)
+	}
+
+	// Overrides replace known models and add new ones
+	path := write("prices.json", `{
+		"bedrock/claude-sonnet-4-20250514": {"input_cost_per_token": 1e-06, "output_cost_per_token": 2e-06},
+		"claude-custom": {"input_cost_per_token": 5e-06, "output_cost_per_token": 6e-06}
+	}`)
+	if err := table.LoadOverrides(path); err != nil {
+		t.Fatal(err)
+	}
+	for model, want := range map[string]float64{"claude-sonnet-4": 1e-06, "claude-custom": 5e-06, "claude-opus-4": 2e-05} {
+		if pricing, err := table.GetModelPricing(model); err != nil || pricing.InputCostPerToken != want {
+			t.Errorf("%s: got %+v, %v, want input price %v", model, pricing, err, want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"ccusage-rainbow/internal/domain/entities"
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// createAnomaliesCommand creates the command that lists abnormally expensive days
+func (c *Controller) createAnomaliesCommand() *cobra.Command {
+	options := entities.DefaultAnomalyOptions()
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "anomalies",
+		Short: "List days that cost abnormally more than the days before them",
+		Long: "Flags days whose cost is more than --threshold robust z-scores (scaled median absolute deviations) " +
+			"above the median of the trailing --window days, and names the model behind each spike",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.Window < 1 {
+				return fmt.Errorf("--window must be at least 1, got %d", options.Window)
+			}
+			options.MinHistory = min(options.MinHistory, options.Window)
+			c.costUseCase.ConfigureAnomalies(options)
+
+			anomalies, err := c.costUseCase.GetAnomalies(cmd.Context())
+			if anomalies == nil && err != nil {
+				return err
+			}
+			if err != nil {
+				// Stale cached data can still be analyzed
+				cmd.PrintErrln("Warning:", err)
+			}
+
+			if asJSON {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(anomalies)
+			}
+
+			if len(anomalies) == 0 {
+				cmd.Println("No anomalies found")
+				return nil
+			}
+			writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', tabwriter.AlignRight)
+			_, _ = fmt.Fprintln(writer, "DATE\tCOST\tBASELINE\tSCORE\tMODEL\tMODEL COST\tMODEL BASELINE\t")
+			for _, anomaly := range anomalies {
+				_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%.1f\t%s\t%s\t%s\t\n",
+					anomaly.Date, c.costUseCase.FormatCost(anomaly.Cost), c.costUseCase.FormatCost(anomaly.Baseline), anomaly.Score,
+					anomaly.Model, c.costUseCase.FormatCost(anomaly.ModelCost), c.costUseCase.FormatCost(anomaly.ModelBaseline))
+			}
+			return writer.Flush()
+		},
+	}
+
+	cmd.Flags().IntVar(&options.Window, "window", options.Window, "number of trailing days the baseline median is taken from")
+	cmd.Flags().Float64Var(&options.Threshold, "threshold", options.Threshold, "robust z-score above which a day is flagged")
+	cmd.Flags().Float64Var(&options.MinExcess, "min-excess", options.MinExcess, "USD above the baseline a day must reach to be flagged")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print the anomalies as JSON, amounts in USD")
+
+	return cmd
+}
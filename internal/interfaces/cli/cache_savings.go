@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"ccusage-rainbow/internal/domain/entities"
+	"fmt"
+	"math"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// createCacheSavingsCommand creates the command that shows prompt-cache efficiency per day or per model
+func (c *Controller) createCacheSavingsCommand() *cobra.Command {
+	var by string
+
+	cmd := &cobra.Command{
+		Use:   "cache-savings",
+		Short: "Show the prompt-cache hit ratio and dollars saved per day or per model",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if by != "day" && by != "model" {
+				return fmt.Errorf("--by must be day or model, got %q", by)
+			}
+
+			costData, err := c.costUseCase.GetCostData(cmd.Context())
+			if costData == nil {
+				return err
+			}
+			if err != nil {
+				// Stale cached data still shows the trend
+				cmd.PrintErrln("Warning:", err)
+			}
+
+			rows := c.pricingUseCase.CacheEfficiencyByDay(costData)
+			if by == "model" {
+				rows = c.pricingUseCase.CacheEfficiencyByModel(costData)
+			}
+			total := c.pricingUseCase.CacheEfficiencyTotal(costData)
+			total.Key = "TOTAL"
+
+			writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', tabwriter.AlignRight)
+			_, _ = fmt.Fprintf(writer, "%s\tINPUT\tCACHE WRITE\tCACHE READ\tHIT RATIO\t\tSAVED\tWRITE PREMIUM\tNET\t\n", strings.ToUpper(by))
+			for _, row := range rows {
+				c.writeCacheEfficiency(writer, &row)
+			}
+			c.writeCacheEfficiency(writer, &total)
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+
+			if total.Unpriced > 0 {
+				cmd.PrintErrf("%d model breakdowns have no known pricing and are left out of the dollar figures\n", total.Unpriced)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&by, "by", "day", "group by day or model")
+
+	return cmd
+}
+
+// writeCacheEfficiency writes one row of the cache-savings table, with a bar charting the hit ratio
+func (c *Controller) writeCacheEfficiency(writer *tabwriter.Writer, efficiency *entities.CacheEfficiency) {
+	const barWidth = 10
+	filled := int(math.Round(efficiency.HitRatio() * barWidth))
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+	_, _ = fmt.Fprintf(writer, "%s\t%d\t%d\t%d\t%.1f%%\t%s\t%s\t%s\t%s\t\n",
+		efficiency.Key, efficiency.InputTokens, efficiency.CacheCreationTokens, efficiency.CacheReadTokens,
+		efficiency.HitRatio()*100, bar, c.costUseCase.FormatCost(efficiency.Saved), c.costUseCase.FormatCost(efficiency.WritePremium),
+		c.formatDelta(efficiency.NetSaved()))
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// createCheckPricingCommand creates the command that compares reported costs with costs recomputed from tokens
+func (c *Controller) createCheckPricingCommand() *cobra.Command {
+	var showAll bool
+	var recalculate bool
+
+	cmd := &cobra.Command{
+		Use:   "check-pricing",
+		Short: "Compare reported costs with costs recomputed from token counts",
+		Long: "Compares the reported cost of every model and day with the cost recomputed from its token counts " +
+			"and the pricing table. With --recalculate, prints the daily report repriced from token counts instead, " +
+			"as JSON that --input reads back",
+		Example: "  ccusage-rainbow check-pricing --recalculate --pricing-file prices.json > repriced.json\n" +
+			"  ccusage-rainbow --input repriced.json",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			costData, err := c.costUseCase.GetCostData(cmd.Context())
+			if costData == nil {
+				return err
+			}
+			if err != nil {
+				// Stale cached data is still worth checking
+				cmd.PrintErrln("Warning:", err)
+			}
+
+			if recalculate {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(c.pricingUseCase.Recalculate(costData))
+			}
+
+			writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', tabwriter.AlignRight)
+			_, _ = fmt.Fprintln(writer, "DATE\tMODEL\tREPORTED\tCOMPUTED\tDELTA\t")
+
+			var reported, computed float64
+			for _, discrepancy := range c.pricingUseCase.CrossCheck(costData) {
+				reported += discrepancy.ReportedCost
+				if !discrepancy.Priced {
+					computed += discrepancy.ReportedCost
+					_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t\n",
+						discrepancy.Date, discrepancy.ModelName, c.costUseCase.FormatCost(discrepancy.ReportedCost), "unpriced", "-")
+					continue
+				}
+				computed += discrepancy.ComputedCost
+				// Hide rounding noise unless asked for every row
+				if !showAll && math.Abs(discrepancy.Delta()) < 0.005 {
+					continue
+				}
+				_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t\n",
+					discrepancy.Date, discrepancy.ModelName, c.costUseCase.FormatCost(discrepancy.ReportedCost),
+					c.costUseCase.FormatCost(discrepancy.ComputedCost), c.formatDelta(discrepancy.Delta()))
+			}
+			_, _ = fmt.Fprintf(writer, "TOTAL\t\t%s\t%s\t%s\t\n",
+				c.costUseCase.FormatCost(reported), c.costUseCase.FormatCost(computed), c.formatDelta(computed-reported))
+
+			return writer.Flush()
+		},
+	}
+
+	cmd.Flags().BoolVar(&showAll, "all", false, "show every row, including ones that match within half a cent")
+	cmd.Flags().BoolVar(&recalculate, "recalculate", false,
+		"print the daily report with every cost recomputed from token counts as JSON, amounts in USD; unpriced models keep their reported cost")
+
+	return cmd
+}
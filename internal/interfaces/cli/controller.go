@@ -2,9 +2,13 @@ package cli
 
 import (
 	"ccusage-rainbow/internal/domain/entities"
+	"ccusage-rainbow/internal/domain/interfaces"
 	"ccusage-rainbow/internal/interfaces/tui"
 	costUseCase "ccusage-rainbow/internal/usecase/cost"
+	pricingUseCase "ccusage-rainbow/internal/usecase/pricing"
 	"ccusage-rainbow/internal/usecase/rainbow"
+	"fmt"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
@@ -14,13 +18,22 @@ import (
 type Controller struct {
 	rainbowUseCase *rainbow.RainbowTextUseCase
 	costUseCase    *costUseCase.CostDisplayUseCase
+	pricingUseCase *pricingUseCase.CostCalculatorUseCase
+	costSources    interfaces.CostSourceSelector
 }
 
 // NewController creates a new CLI controller
-func NewController(rainbowUseCase *rainbow.RainbowTextUseCase, costUseCase *costUseCase.CostDisplayUseCase) *Controller {
+func NewController(
+	rainbowUseCase *rainbow.RainbowTextUseCase,
+	costUseCase *costUseCase.CostDisplayUseCase,
+	pricingUseCase *pricingUseCase.CostCalculatorUseCase,
+	costSources interfaces.CostSourceSelector,
+) *Controller {
 	return &Controller{
 		rainbowUseCase: rainbowUseCase,
 		costUseCase:    costUseCase,
+		pricingUseCase: pricingUseCase,
+		costSources:    costSources,
 	}
 }
 
@@ -28,47 +41,172 @@ func NewController(rainbowUseCase *rainbow.RainbowTextUseCase, costUseCase *cost
 func (c *Controller) CreateRootCommand() *cobra.Command {
 	var useBankruptMode bool
 	var useHiMode bool
+	var source string
+	var pricingFile string
+	var refreshInterval time.Duration
+	var timeout time.Duration
+	var metric string
+	var since, until string
+	var cacheTTL time.Duration
+	var noCache bool
+	var inputPaths []string
+	var claudeDirs []string
+	var currency string
+	var ratesFile string
+	var budgetFile string
+	var planFile string
+	var plan string
+	var billingDay int
+	var groupModels string
+	var font string
+	var renderMode string
 
 	rootCmd := &cobra.Command{
 		Use:   "ccusage-rainbow",
 		Short: "Display rainbow colored total cost from ccusage",
 		Long:  "A CLI tool that fetches total cost from ccusage and displays it as large ASCII text with animated rainbow colors",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			dateRange, err := entities.ParseDateRange(since, until, time.Now())
+			if err != nil {
+				return fmt.Errorf("--since/--until: %w", err)
+			}
+			stdinInputs := 0
+			for _, path := range inputPaths {
+				if path == "-" {
+					stdinInputs++
+				}
+			}
+			if stdinInputs > 1 {
+				return fmt.Errorf("--input - (stdin) can only be given once")
+			}
+			sourceOptions := interfaces.CostSourceOptions{
+				Source:     source,
+				Timeout:    timeout,
+				DateRange:  dateRange,
+				InputPaths: inputPaths,
+				ClaudeDirs: claudeDirs,
+			}
+			if err := c.costSources.SelectSource(sourceOptions); err != nil {
+				return err
+			}
+			c.costUseCase.SelectCacheSource(sourceOptions)
+			c.costUseCase.SelectDateRange(dateRange)
+			modelGrouping, err := entities.ParseModelGrouping(groupModels)
+			if err != nil {
+				return err
+			}
+			c.costUseCase.SelectModelGrouping(modelGrouping)
+			c.pricingUseCase.SelectModelGrouping(modelGrouping)
+			if err := c.costUseCase.SelectPlan(planFile, plan, billingDay); err != nil {
+				return err
+			}
+			if err := c.costUseCase.SelectCurrency(currency, ratesFile); err != nil {
+				return err
+			}
+			// Saved reports are already local, caching them would only shadow live data
+			c.costUseCase.ConfigureCache(!noCache && len(inputPaths) == 0, cacheTTL)
+			if pricingFile != "" {
+				if err := c.pricingUseCase.LoadPricingOverrides(pricingFile); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return c.runTUI(useBankruptMode, useHiMode)
+			if err := c.rainbowUseCase.SelectFont(font); err != nil {
+				return err
+			}
+			if err := c.rainbowUseCase.SelectRenderMode(renderMode); err != nil {
+				return err
+			}
+			if err := c.costUseCase.SelectMetric(metric); err != nil {
+				return err
+			}
+			if err := c.costUseCase.SelectBudget(budgetFile); err != nil {
+				return err
+			}
+			// The countdown follows the active block live unless told otherwise
+			if metric == costUseCase.MetricCountdown && !cmd.Flags().Changed("refresh") {
+				refreshInterval = time.Minute
+			}
+			if refreshInterval < 0 {
+				return fmt.Errorf("--refresh must not be negative, got %s", refreshInterval)
+			}
+			return c.runTUI(useBankruptMode, useHiMode, refreshInterval)
 		},
 	}
 
+	rootCmd.PersistentFlags().StringVar(&source, "source", interfaces.CostSourceAuto,
+		"where to read usage from: auto (npx, falling back to native), npx (ccusage@latest) or native (Claude Code JSONL logs)")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 2*time.Minute, "give up on a single usage fetch after this long (0 disables)")
+	rootCmd.PersistentFlags().StringArrayVarP(&inputPaths, "input", "i", nil,
+		"read a saved ccusage -j report from this file, or - for stdin, instead of --source; repeat to merge several")
+	rootCmd.PersistentFlags().StringArrayVar(&claudeDirs, "claude-dir", nil,
+		"read Claude Code logs from this config directory, e.g. one synced from another machine; repeat to merge several")
+	rootCmd.PersistentFlags().StringVar(&since, "since", "",
+		"only count usage from this date: YYYY-MM-DD, today, yesterday, Nd (last N days), this-week, this-month or last-month")
+	rootCmd.PersistentFlags().StringVar(&until, "until", "",
+		"only count usage up to this date, in the same forms as --since; Nd ends before the last N days")
+	rootCmd.PersistentFlags().DurationVar(&cacheTTL, "cache-ttl", 10*time.Minute,
+		"show cached usage younger than this without fetching; older cache is shown while fetching in the background")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "neither read nor write the usage cache")
+	rootCmd.PersistentFlags().StringVar(&currency, "currency", entities.CurrencyUSD,
+		"display costs in this currency, e.g. EUR, JPY or GBP, converted with the rates in --rates-file")
+	rootCmd.PersistentFlags().StringVar(&ratesFile, "rates-file", "",
+		"JSON file with exchange rates, e.g. {\"base\": \"USD\", \"rates\": {\"EUR\": 0.92}} (default $XDG_CONFIG_HOME/ccusage-rainbow/rates.json)")
+	rootCmd.PersistentFlags().StringVar(&groupModels, "group-models", string(entities.ModelGroupingModel),
+		"how model breakdowns are grouped: model (e.g. Sonnet 4, snapshots merged) or family (Opus, Sonnet, Haiku)")
+	rootCmd.PersistentFlags().StringVar(&plan, "plan", "",
+		"subscription plan to compare API-equivalent costs with: pro, max-5x, max-20x or a monthly price in USD, e.g. 50")
+	rootCmd.PersistentFlags().IntVar(&billingDay, "billing-day", 0, "day of the month the plan's billing month starts on (default 1)")
+	rootCmd.PersistentFlags().StringVar(&planFile, "plan-file", "",
+		"JSON plan, e.g. {\"plan\": \"max-5x\", \"billingDay\": 15} (default $XDG_CONFIG_HOME/ccusage-rainbow/plan.json if it exists)")
+	rootCmd.PersistentFlags().StringVar(&pricingFile, "pricing-file", "",
+		"LiteLLM-style JSON file with model prices that override the built-in table")
+
+	rootCmd.Flags().StringVar(&metric, "show", costUseCase.MetricTotal,
+		"which cost to display: total, today, month (this month), session (latest session), block (active 5-hour block), forecast (projected month-end cost), cache-savings (net saved by prompt caching), countdown (time left in the active 5-hour block) or plan-value (this billing month's cost as a multiple of the --plan price)")
+	rootCmd.Flags().StringVar(&budgetFile, "budget-file", "",
+		"JSON budget, e.g. {\"amount\": 200, \"period\": \"month\", \"warningPercent\": 80, \"criticalPercent\": 100} (default $XDG_CONFIG_HOME/ccusage-rainbow/budget.json if it exists)")
+	rootCmd.Flags().StringVar(&font, "font", "builtin",
+		"font to draw the text in: builtin, a FIGlet .flf file, or the name of one in $XDG_CONFIG_HOME/ccusage-rainbow/fonts (see the fonts command)")
+	rootCmd.Flags().StringVar(&renderMode, "render", string(interfaces.RenderModeBlock),
+		"how finely the built-in or a bitmap font is drawn: block (a █ per pixel), half (2 pixels per cell with ▀ and ▄), quadrant (2x2 pixels per cell) or braille (2x4 pixels per cell)")
+	rootCmd.Flags().DurationVar(&refreshInterval, "refresh", 0, "re-fetch the cost at this interval while running, e.g. 60s (0 disables)")
 	rootCmd.Flags().BoolVarP(&useBankruptMode, "bankrupt", "", false, "")
 	_ = rootCmd.Flags().MarkHidden("bankrupt")
 	rootCmd.Flags().BoolVarP(&useHiMode, "hi", "", false, "")
 	_ = rootCmd.Flags().MarkHidden("hi")
 
+	rootCmd.AddCommand(c.createCheckPricingCommand())
+	rootCmd.AddCommand(c.createSourcesCommand())
+	rootCmd.AddCommand(c.createCacheSavingsCommand())
+	rootCmd.AddCommand(c.createAnomaliesCommand())
+	rootCmd.AddCommand(c.createPlanValueCommand())
+	rootCmd.AddCommand(c.createSimulateCommand())
+	rootCmd.AddCommand(c.createFontsCommand())
+
 	return rootCmd
 }
 
 // runTUI starts the TUI application
-func (c *Controller) runTUI(useBankruptMode bool, useHiMode bool) error {
-	var text *entities.Text
-	var err error
+func (c *Controller) runTUI(useBankruptMode bool, useHiMode bool, refreshInterval time.Duration) error {
+	var model *tui.Model
 
 	if useHiMode {
 		// Hidden option to display "HELLO"
-		text = entities.NewText("HELLO")
+		model = tui.NewModel(entities.NewText("HELLO"), c.rainbowUseCase)
 	} else if useBankruptMode {
-		// Hidden bankrupt mode - display large cost
-		text = entities.NewText("$9999.99")
+		// Hidden bankrupt mode - display large cost, far over budget
+		model = tui.NewModel(entities.NewText("$9999.99"), c.rainbowUseCase)
+		model.SetBudgetStatus(entities.NewBudget(100, entities.BudgetPeriodMonth).Evaluate(9999.99))
 	} else {
-		// Fetch cost data and format it
-		text, err = c.costUseCase.GetCostText()
-		if err != nil {
-			// Fallback to error display
-			text = entities.NewText("ERROR")
-		}
+		// Fetch cost data in the background while the loading animation runs
+		model = tui.NewCostModel(c.rainbowUseCase, c.costUseCase, refreshInterval)
 	}
 
-	model := tui.NewModel(text, c.rainbowUseCase)
 	program := tea.NewProgram(model, tea.WithAltScreen())
 
-	_, err = program.Run()
+	_, err := program.Run()
 	return err
 }
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"ccusage-rainbow/internal/domain/entities"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// createFontsCommand creates the command that lists the fonts --font can select
+func (c *Controller) createFontsCommand() *cobra.Command {
+	var sample string
+
+	cmd := &cobra.Command{
+		Use:   "fonts",
+		Short: "List the fonts --font can select",
+		Long: "Lists the built-in font and the fonts in $XDG_CONFIG_HOME/ccusage-rainbow/fonts " +
+			"(or the platform equivalent). With --sample, every font draws the sample text.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fonts, err := c.rainbowUseCase.ListFonts()
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			for _, font := range fonts {
+				_, _ = fmt.Fprintln(out, font)
+				if sample == "" {
+					continue
+				}
+				if err := c.rainbowUseCase.SelectFont(font); err != nil {
+					cmd.PrintErrln("Warning:", err)
+					continue
+				}
+				rendered, err := c.rainbowUseCase.RenderPlainText(entities.NewText(sample))
+				if err != nil {
+					return err
+				}
+				_, _ = fmt.Fprintf(out, "%s\n\n", rendered)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&sample, "sample", "", "draw this text in every font, e.g. $12.34")
+
+	return cmd
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// createPlanValueCommand creates the command that compares every billing month's cost with the plan price
+func (c *Controller) createPlanValueCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "plan-value",
+		Short: "Show the API-equivalent cost of every billing month as a multiple of the plan price",
+		Long: "Compares the API-equivalent cost of every billing month with the price of the subscription plan " +
+			"given with --plan or the plan file, e.g. 12.4x means the usage would have cost 12.4 times the plan price",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			plan, values, err := c.costUseCase.GetPlanValues(cmd.Context())
+			if values == nil && err != nil {
+				return err
+			}
+			if err != nil {
+				// Stale cached data still shows the history
+				cmd.PrintErrln("Warning:", err)
+			}
+
+			if len(values) == 0 {
+				cmd.Println("No usage found")
+				return nil
+			}
+			cmd.Printf("%s plan, billing months starting on day %d\n\n", plan.DisplayName(), plan.BillingDay)
+
+			writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', tabwriter.AlignRight)
+			_, _ = fmt.Fprintln(writer, "BILLING MONTH\tDAYS\tAPI COST\tPLAN PRICE\tMULTIPLE\t")
+			var cost, price float64
+			for _, value := range values {
+				_, _ = fmt.Fprintf(writer, "%s - %s\t%d\t%s\t%s\t%.1fx\t\n",
+					value.Start.Format("2006-01-02"), value.End.AddDate(0, 0, -1).Format("2006-01-02"),
+					value.Days, c.costUseCase.FormatCost(value.Cost), c.costUseCase.FormatCost(value.Price), value.Multiple)
+				cost += value.Cost
+				price += value.Price
+			}
+			_, _ = fmt.Fprintf(writer, "TOTAL\t\t%s\t%s\t%.1fx\t\n",
+				c.costUseCase.FormatCost(cost), c.costUseCase.FormatCost(price), cost/price)
+			return writer.Flush()
+		},
+	}
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"ccusage-rainbow/internal/domain/entities"
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// createSimulateCommand creates the command that reprices usage as if models had been substituted
+func (c *Controller) createSimulateCommand() *cobra.Command {
+	var ruleValues []string
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "simulate --rule FROM=TO [--rule ...]",
+		Short: "Show what usage would have cost with other models",
+		Long: "Reprices every model's token counts from the pricing table as used and with the tokens moved by the " +
+			"substitution rules, and shows the difference per day and overall. A rule moves a model, undated model " +
+			"or family (opus, sonnet, haiku) to another model, e.g. opus=claude-sonnet-4, or splits it by percentage, " +
+			"e.g. opus=claude-sonnet-4:70,claude-haiku-4-5:30; shares below 100% leave the rest where it was. " +
+			"The first matching rule applies.",
+		Example: "  ccusage-rainbow simulate --since last-month --until last-month --rule opus=claude-sonnet-4",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(ruleValues) == 0 {
+				return fmt.Errorf("give at least one --rule, e.g. --rule opus=claude-sonnet-4")
+			}
+			rules := make([]entities.SubstitutionRule, len(ruleValues))
+			for i, value := range ruleValues {
+				rule, err := entities.ParseSubstitutionRule(value)
+				if err != nil {
+					return err
+				}
+				rules[i] = rule
+			}
+
+			costData, err := c.costUseCase.GetCostData(cmd.Context())
+			if costData == nil {
+				return err
+			}
+			if err != nil {
+				// Stale cached data can still be simulated
+				cmd.PrintErrln("Warning:", err)
+			}
+
+			simulation, err := c.pricingUseCase.Simulate(costData, rules)
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(simulation)
+			}
+
+			writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', tabwriter.AlignRight)
+			_, _ = fmt.Fprintln(writer, "DATE\tACTUAL\tSIMULATED\tDELTA\tCHANGE\t")
+			for _, day := range simulation.Days {
+				_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t\n",
+					day.Date, c.costUseCase.FormatCost(day.ActualCost), c.costUseCase.FormatCost(day.SimulatedCost),
+					c.formatDelta(day.Delta), formatChange(day.Delta, day.ActualCost))
+			}
+			_, _ = fmt.Fprintf(writer, "TOTAL\t%s\t%s\t%s\t%s\t\n",
+				c.costUseCase.FormatCost(simulation.ActualCost), c.costUseCase.FormatCost(simulation.SimulatedCost),
+				c.formatDelta(simulation.Delta), formatChange(simulation.Delta, simulation.ActualCost))
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+
+			if simulation.Unpriced > 0 {
+				cmd.PrintErrf("%d model breakdowns have no known pricing and count at their reported cost\n", simulation.Unpriced)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&ruleValues, "rule", nil,
+		"substitution rule FROM=TO or FROM=TO:PERCENT,TO:PERCENT; repeat for several models")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print the simulation as JSON, amounts in USD")
+
+	return cmd
+}
+
+// formatDelta formats a difference of USD amounts in the selected currency with its sign, e.g. +$1.20
+func (c *Controller) formatDelta(delta float64) string {
+	formatted := c.costUseCase.FormatCost(delta)
+	if delta > 0 && formatted != c.costUseCase.FormatCost(0) {
+		return "+" + formatted
+	}
+	return formatted
+}
+
+// formatChange formats a delta as a percentage of the actual cost
+func formatChange(delta, actual float64) string {
+	if actual == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f%%", delta/actual*100)
+}
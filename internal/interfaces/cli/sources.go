@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"ccusage-rainbow/internal/domain/entities"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// createSourcesCommand creates the command that breaks the merged cost down by source
+func (c *Controller) createSourcesCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "sources",
+		Short: "Show how much each input file or Claude directory contributes to the total",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sources, err := c.costUseCase.GetCostDataBySource(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			responses := make([]*entities.CostResponse, len(sources))
+			for i, source := range sources {
+				responses[i] = source.Response
+			}
+			total := entities.MergeCostResponses(responses...)
+
+			writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', tabwriter.AlignRight)
+			_, _ = fmt.Fprintln(writer, "SOURCE\tDAYS\tTOKENS\tCOST\tSHARE\t")
+			for _, source := range sources {
+				share := 0.0
+				if total.Totals.TotalCost > 0 {
+					share = source.Response.Totals.TotalCost / total.Totals.TotalCost * 100
+				}
+				_, _ = fmt.Fprintf(writer, "%s\t%d\t%d\t%s\t%.1f%%\t\n",
+					source.Source, len(source.Response.Daily), source.Response.Totals.TotalTokens,
+					c.costUseCase.FormatCost(source.Response.Totals.TotalCost), share)
+			}
+			_, _ = fmt.Fprintf(writer, "TOTAL\t%d\t%d\t%s\t\t\n",
+				len(total.Daily), total.Totals.TotalTokens, c.costUseCase.FormatCost(total.Totals.TotalCost))
+
+			return writer.Flush()
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package tui
+
+import (
+	"ccusage-rainbow/internal/domain/entities"
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// anomalyStyle is used to flag abnormally expensive days under the rainbow text
+var anomalyStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5F5F")).Bold(true)
+
+// renderAnomalyFlags renders a one-line warning about the latest spend anomaly, or an empty
+// string when there is none
+func renderAnomalyFlags(anomalies []entities.CostAnomaly, formatCost func(float64) string, maxWidth int) string {
+	if len(anomalies) == 0 {
+		return ""
+	}
+
+	latest := anomalies[len(anomalies)-1]
+	flag := fmt.Sprintf("⚠ Spend spike on %s: %s vs %s typical", latest.Date, formatCost(latest.Cost), formatCost(latest.Baseline))
+	if latest.Model != "" {
+		flag += fmt.Sprintf(", mostly %s (+%s)", latest.Model, formatCost(latest.ModelCost-latest.ModelBaseline))
+	}
+	if len(anomalies) > 1 {
+		flag += fmt.Sprintf(" · %d more this week", len(anomalies)-1)
+	}
+	if lipgloss.Width(flag) > maxWidth {
+		flag = clipLines(wrap(flag, maxWidth), 2)
+	}
+	return anomalyStyle.Render(flag)
+}
@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"ccusage-rainbow/internal/domain/entities"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// gaugeColors colors the gauge by budget level
+var gaugeColors = map[entities.BudgetLevel]lipgloss.Color{
+	entities.BudgetLevelNormal:   lipgloss.Color("#00C000"),
+	entities.BudgetLevelWarning:  lipgloss.Color("#FFA500"),
+	entities.BudgetLevelCritical: lipgloss.Color("#FF0000"),
+}
+
+// periodNames describes the budget periods in the gauge label
+var periodNames = map[entities.BudgetPeriod]string{
+	entities.BudgetPeriodDay:   "today",
+	entities.BudgetPeriodWeek:  "this week",
+	entities.BudgetPeriodMonth: "this month",
+}
+
+// renderBudgetGauge renders a one-line percentage gauge of spending against the budget
+func renderBudgetGauge(status *entities.BudgetStatus, formatCost func(float64) string, maxWidth int) string {
+	label := fmt.Sprintf(" %.0f%% · %s of %s %s", status.Percent,
+		formatCost(status.Spent), formatCost(status.Budget.Amount), periodNames[status.Budget.Period])
+	if status.Level == entities.BudgetLevelCritical && status.Percent >= 100 {
+		label += " · over budget"
+	}
+
+	// The bar takes what the label leaves, up to 40 cells
+	barWidth := min(40, maxWidth-lipgloss.Width(label)-2)
+	style := lipgloss.NewStyle().Foreground(gaugeColors[status.Level])
+	if barWidth < 5 {
+		return style.Render(strings.TrimSpace(label))
+	}
+
+	filled := int(math.Round(math.Min(status.Percent, 100) / 100 * float64(barWidth)))
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+	return style.Render("▕"+bar+"▏") + statusStyle.Render(label)
+}
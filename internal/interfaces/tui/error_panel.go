@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"ccusage-rainbow/internal/domain/entities"
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	errorTitleStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FF5F5F"))
+	errorHintStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFD75F"))
+	errorDetailStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#A8A8A8"))
+	errorPanelStyle  = lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(lipgloss.Color("#FF5F5F")).
+				Padding(0, 1)
+)
+
+// renderErrorPanel renders a bordered panel describing a fetch error.
+// When expanded, the captured stderr is shown in full (clipped to maxHeight).
+func renderErrorPanel(err error, expanded bool, maxWidth, maxHeight int) string {
+	// Border and padding take 4 columns
+	contentWidth := maxWidth - 4
+	if contentWidth > 100 {
+		contentWidth = 100
+	}
+	if contentWidth < 20 {
+		contentWidth = 20
+	}
+
+	lines := []string{
+		errorTitleStyle.Render("Failed to fetch usage data"),
+		wrap(err.Error(), contentWidth),
+	}
+
+	var stderr string
+	var fetchErr *entities.CostFetchError
+	if errors.As(err, &fetchErr) {
+		if hint := fetchErr.Hint(); hint != "" {
+			lines = append(lines, errorHintStyle.Render(wrap(hint, contentWidth)))
+		}
+		stderr = fetchErr.Stderr
+	}
+
+	keys := "[r] retry  [q] quit"
+	if stderr != "" {
+		if expanded {
+			keys = "[r] retry  [d] hide details  [q] quit"
+			// Title, message, hint, blank, keys, blank and the border rows
+			available := maxHeight - len(lines) - 5
+			lines = append(lines, "", errorDetailStyle.Render(clipLines(wrap(stderr, contentWidth), available)))
+		} else {
+			keys = "[r] retry  [d] details  [q] quit"
+			lines = append(lines, errorDetailStyle.Render(clipLines(wrap(lastLine(stderr), contentWidth), 1)))
+		}
+	}
+	lines = append(lines, "", keys)
+
+	return errorPanelStyle.Width(contentWidth + 2).Render(strings.Join(lines, "\n"))
+}
+
+// wrap wraps text to the given width
+func wrap(text string, width int) string {
+	return lipgloss.NewStyle().Width(width).Render(text)
+}
+
+// clipLines keeps at most maxLines lines, marking the cut
+func clipLines(text string, maxLines int) string {
+	if maxLines < 1 {
+		maxLines = 1
+	}
+	lines := strings.Split(text, "\n")
+	if len(lines) <= maxLines {
+		return text
+	}
+	if maxLines == 1 {
+		return lines[0]
+	}
+	return strings.Join(append(lines[:maxLines-1], "..."), "\n")
+}
+
+// lastLine returns the last non-empty line of text, which is usually the actual error
+func lastLine(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
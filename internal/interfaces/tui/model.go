@@ -3,21 +3,74 @@ package tui
 import (
 	"ccusage-rainbow/internal/domain/entities"
 	"ccusage-rainbow/internal/domain/interfaces"
+	"ccusage-rainbow/internal/usecase/cost"
 	"ccusage-rainbow/internal/usecase/rainbow"
+	"context"
+	"errors"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 // TickMsg represents a timer tick for animation
 type TickMsg time.Time
 
+// RefreshTickMsg represents a timer tick for refreshing cost data.
+// ID identifies the schedule so ticks made obsolete by a manual retry are ignored.
+type RefreshTickMsg struct {
+	Time time.Time
+	ID   int
+}
+
+// ClockTickMsg represents a once-a-second tick that advances the block countdown
+type ClockTickMsg time.Time
+
+// CostTextMsg carries the result of a background cost fetch
+type CostTextMsg struct {
+	Text      *entities.Text
+	Caption   string
+	Budget    *entities.BudgetStatus
+	Anomalies []entities.CostAnomaly
+	Countdown bool
+	Block     *entities.Block
+	Err       error
+	FetchedAt time.Time
+}
+
+// loadingText is displayed while the first cost fetch is running
+var loadingText = entities.NewText("LOADING")
+
+// statusStyle is used for the status line under the rainbow text
+var statusStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#808080"))
+
+// captionStyle is used for the metric's caption, such as the forecast band, under the rainbow text
+var captionStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#C0C0C0"))
+
 // Model represents the TUI model following Clean Architecture
 type Model struct {
 	text       *entities.Text
+	caption    string
+	budget     *entities.BudgetStatus
+	anomalies  []entities.CostAnomaly
+	countdown  bool
+	block      *entities.Block
+	clockOn    bool
 	useCase    *rainbow.RainbowTextUseCase
 	dimensions interfaces.DisplayDimensions
+
+	costUseCase     *cost.CostDisplayUseCase
+	refreshInterval time.Duration
+	lastRefresh     time.Time
+	refreshID       int
+	fetchErr        error
+	staleSince      time.Time
+	showDetails     bool
+	loading         bool
+	fetching        bool
+	ctx             context.Context
+	cancel          context.CancelFunc
 }
 
 // NewModel creates a new TUI model
@@ -28,11 +81,53 @@ func NewModel(text *entities.Text, useCase *rainbow.RainbowTextUseCase) *Model {
 	}
 }
 
+// SetBudgetStatus shows the budget gauge and escalates the animation with the budget level;
+// nil hides the gauge
+func (m *Model) SetBudgetStatus(status *entities.BudgetStatus) {
+	m.budget = status
+	if status == nil {
+		m.useCase.SetBudgetLevel(entities.BudgetLevelNormal)
+		return
+	}
+	m.useCase.SetBudgetLevel(status.Level)
+}
+
+// NewCostModel creates a TUI model that shows the cached cost text, or a loading animation when
+// nothing is cached, while fetching the cost text in the background. The text is then re-fetched
+// every refreshInterval (0 disables refreshing).
+func NewCostModel(useCase *rainbow.RainbowTextUseCase, costUseCase *cost.CostDisplayUseCase, refreshInterval time.Duration) *Model {
+	ctx, cancel := context.WithCancel(context.Background())
+	model := &Model{
+		text:            loadingText,
+		useCase:         useCase,
+		costUseCase:     costUseCase,
+		refreshInterval: refreshInterval,
+		loading:         true,
+		fetching:        true,
+		ctx:             ctx,
+		cancel:          cancel,
+	}
+
+	if cached := costUseCase.GetCachedCostText(); cached != nil {
+		model.text = cached.Text
+		model.caption = cached.Caption
+		model.SetBudgetStatus(cached.Budget)
+		model.anomalies = cached.Anomalies
+		model.lastRefresh = cached.FetchedAt
+		model.loading = false
+		// Revalidate in the background unless the cache is still fresh
+		model.fetching = !cached.Fresh
+	}
+
+	return model
+}
+
 // Init initializes the model
 func (m *Model) Init() tea.Cmd {
-	return tea.Tick(m.useCase.GetAnimationInterval(), func(t time.Time) tea.Msg {
-		return TickMsg(t)
-	})
+	if m.fetching {
+		return tea.Batch(m.animationTick(), m.fetchCostText())
+	}
+	return tea.Batch(m.animationTick(), m.refreshTick())
 }
 
 // Update handles messages and updates the model
@@ -41,7 +136,21 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "q", "ctrl+c", "esc":
+			// Stop any running fetch so the ccusage child process does not outlive us
+			if m.cancel != nil {
+				m.cancel()
+			}
 			return m, tea.Quit
+		case "r":
+			// Retry after a failed fetch
+			if (m.fetchErr != nil || !m.staleSince.IsZero()) && !m.fetching {
+				m.fetching = true
+				return m, m.fetchCostText()
+			}
+		case "d":
+			if m.fetchErr != nil {
+				m.showDetails = !m.showDetails
+			}
 		}
 	case tea.WindowSizeMsg:
 		m.dimensions = interfaces.DisplayDimensions{
@@ -50,13 +159,165 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	case TickMsg:
 		m.useCase.AdvanceAnimation()
-		return m, tea.Tick(m.useCase.GetAnimationInterval(), func(t time.Time) tea.Msg {
-			return TickMsg(t)
-		})
+		return m, m.animationTick()
+	case RefreshTickMsg:
+		if msg.ID != m.refreshID || m.fetching {
+			return m, nil
+		}
+		m.fetching = true
+		return m, m.fetchCostText()
+	case CostTextMsg:
+		var staleErr *entities.StaleDataError
+		switch {
+		case errors.As(msg.Err, &staleErr):
+			// The fetch failed but cached data was available, show it marked as stale
+			m.text = msg.Text
+			m.caption = msg.Caption
+			m.SetBudgetStatus(msg.Budget)
+			m.anomalies = msg.Anomalies
+			m.staleSince = staleErr.FetchedAt
+			m.fetchErr = nil
+		case msg.Err != nil:
+			// Keep showing the previous value, the error panel reports the failure; without
+			// one only the panel is shown
+			m.fetchErr = msg.Err
+			if m.lastRefresh.IsZero() {
+				m.text = nil
+			}
+		default:
+			m.text = msg.Text
+			m.caption = msg.Caption
+			m.SetBudgetStatus(msg.Budget)
+			m.anomalies = msg.Anomalies
+			m.countdown = msg.Countdown
+			m.block = msg.Block
+			m.lastRefresh = msg.FetchedAt
+			m.fetchErr = nil
+			m.staleSince = time.Time{}
+			m.showDetails = false
+		}
+		m.loading = false
+		m.fetching = false
+		if m.countdown && !m.clockOn {
+			m.clockOn = true
+			return m, tea.Batch(m.refreshTick(), m.clockTick())
+		}
+		return m, m.refreshTick()
+	case ClockTickMsg:
+		if !m.countdown {
+			m.clockOn = false
+			return m, nil
+		}
+		now := time.Time(msg)
+		display := m.costUseCase.CountdownDisplay(m.block, now)
+		m.text = display.Text
+		m.caption = display.Caption
+		// Fetch the next block as soon as this one resets
+		if m.block != nil && m.block.Remaining(now) == 0 && !m.fetching {
+			m.block = nil
+			m.fetching = true
+			return m, tea.Batch(m.clockTick(), m.fetchCostText())
+		}
+		return m, m.clockTick()
 	}
 	return m, nil
 }
 
+// animationTick schedules the next animation frame
+func (m *Model) animationTick() tea.Cmd {
+	return tea.Tick(m.useCase.GetAnimationInterval(), func(t time.Time) tea.Msg {
+		return TickMsg(t)
+	})
+}
+
+// clockTick schedules the next countdown update on the next full second
+func (m *Model) clockTick() tea.Cmd {
+	return tea.Every(time.Second, func(t time.Time) tea.Msg {
+		return ClockTickMsg(t)
+	})
+}
+
+// refreshTick schedules the next cost refresh, or nothing when refreshing is disabled
+func (m *Model) refreshTick() tea.Cmd {
+	if m.refreshInterval <= 0 {
+		return nil
+	}
+	m.refreshID++
+	id := m.refreshID
+	return tea.Tick(m.refreshInterval, func(t time.Time) tea.Msg {
+		return RefreshTickMsg{Time: t, ID: id}
+	})
+}
+
+// fetchCostText fetches the cost text in the background
+func (m *Model) fetchCostText() tea.Cmd {
+	costUseCase := m.costUseCase
+	ctx := m.ctx
+	return func() tea.Msg {
+		display, err := costUseCase.GetCostDisplay(ctx)
+		return CostTextMsg{
+			Text:      display.Text,
+			Caption:   display.Caption,
+			Budget:    display.Budget,
+			Anomalies: display.Anomalies,
+			Countdown: display.Countdown,
+			Block:     display.Block,
+			Err:       err,
+			FetchedAt: time.Now(),
+		}
+	}
+}
+
+// statusLine returns the line shown under the rainbow text, or an empty string
+func (m *Model) statusLine() string {
+	if m.loading {
+		return "Fetching usage data..."
+	}
+	if m.fetching {
+		return "Refreshing..."
+	}
+	if !m.staleSince.IsZero() {
+		return "Stale since " + m.staleSince.Format("15:04") + " (offline? press r to retry)"
+	}
+	if m.refreshInterval <= 0 {
+		return ""
+	}
+	if m.lastRefresh.IsZero() {
+		return "Not refreshed yet"
+	}
+	return "Last refresh " + m.lastRefresh.Format("15:04:05")
+}
+
+// formatCost formats a USD amount in the display currency
+func (m *Model) formatCost(amount float64) string {
+	if m.costUseCase == nil {
+		return entities.FormatCost(amount)
+	}
+	return m.costUseCase.FormatCost(amount)
+}
+
+// footerBlocks returns the blocks shown under the rainbow text, each centered as a unit
+func (m *Model) footerBlocks() []string {
+	var blocks []string
+	if m.caption != "" && !m.loading {
+		blocks = append(blocks, captionStyle.Render(m.caption))
+	}
+	if m.budget != nil && !m.loading {
+		blocks = append(blocks, renderBudgetGauge(m.budget, m.formatCost, m.dimensions.Width))
+	}
+	if flags := renderAnomalyFlags(m.anomalies, m.formatCost, m.dimensions.Width); flags != "" && !m.loading {
+		blocks = append(blocks, flags)
+	}
+	if status := m.statusLine(); status != "" {
+		blocks = append(blocks, statusStyle.Render(status))
+	}
+	if m.fetchErr != nil {
+		// Leave at least half the screen for the rainbow text
+		blocks = append(blocks, renderErrorPanel(m.fetchErr, m.showDetails, m.dimensions.Width, m.dimensions.Height/2))
+	}
+	return blocks
+}
+
 // View renders the current view
 func (m *Model) View() string {
 	// Handle case when dimensions are not set yet
@@ -64,8 +325,17 @@ func (m *Model) View() string {
 		return "Loading..."
 	}
 
+	footer := m.footerBlocks()
+	if m.text == nil {
+		return m.layout(nil, footer)
+	}
+	availableHeight := m.dimensions.Height
+	for _, block := range footer {
+		availableHeight -= lipgloss.Height(block) + 1 // Block plus a blank separator
+	}
+
 	// Select optimal font size based on terminal dimensions
-	fontSize, err := m.useCase.SelectOptimalFontSize(m.text, m.dimensions.Width, m.dimensions.Height)
+	fontSize, err := m.useCase.SelectOptimalFontSize(m.text, m.dimensions.Width, availableHeight)
 	if err != nil {
 		return "Error: " + err.Error()
 	}
@@ -111,6 +381,25 @@ func (m *Model) View() string {
 		centeredLines = append(centeredLines, strings.Repeat(" ", globalPadding)+line)
 	}
 
+	return m.layout(centeredLines, footer)
+}
+
+// layout adds the footer blocks centered under the centered text lines and centers the whole
+// vertically
+func (m *Model) layout(centeredLines []string, footer []string) string {
+	for _, block := range footer {
+		blockPadding := (m.dimensions.Width - lipgloss.Width(block)) / 2
+		if blockPadding < 0 {
+			blockPadding = 0
+		}
+		if len(centeredLines) > 0 {
+			centeredLines = append(centeredLines, "")
+		}
+		for _, line := range strings.Split(block, "\n") {
+			centeredLines = append(centeredLines, strings.Repeat(" ", blockPadding)+line)
+		}
+	}
+
 	// Center vertically
 	content := strings.Join(centeredLines, "\n")
 	verticalPadding := (m.dimensions.Height - len(centeredLines)) / 2
@@ -3,30 +3,607 @@ package cost
 import (
 	"ccusage-rainbow/internal/domain/entities"
 	"ccusage-rainbow/internal/domain/interfaces"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 )
 
+// Metrics that can be selected as the displayed cost
+const (
+	MetricTotal    = "total"    // All-time total from the daily report
+	MetricToday    = "today"    // Today's cost from the daily report
+	MetricMonth    = "month"    // This month's cost from the monthly report
+	MetricSession  = "session"  // Cost of the most recently active session
+	MetricBlock    = "block"    // Cost of the active 5-hour billing block
+	MetricForecast = "forecast" // Projected cost of this month at its end, from the daily report
+	// Net dollars saved by prompt caching, from the daily report
+	MetricCacheSavings = "cache-savings"
+	// Time left in the active 5-hour billing block, counting down live
+	MetricCountdown = "countdown"
+	// API-equivalent cost of this billing month as a multiple of the plan price, from the daily report
+	MetricPlanValue = "plan-value"
+)
+
+// recentAnomalyDays is how far back an anomaly is still flagged in the display
+const recentAnomalyDays = 7
+
+// metrics lists the selectable metrics in the order they are documented
+var metrics = []string{MetricTotal, MetricToday, MetricMonth, MetricSession, MetricBlock, MetricForecast, MetricCacheSavings, MetricCountdown, MetricPlanValue}
+
+// CostDisplay represents the cost text to display and the caption shown under it
+type CostDisplay struct {
+	Text    *entities.Text
+	Caption string                 // Empty when the metric has nothing to add
+	Budget  *entities.BudgetStatus // Nil when no budget is set, amounts in USD
+	// Spend anomalies of the last week, oldest first; amounts in USD
+	Anomalies []entities.CostAnomaly
+	// Countdown is set for the countdown metric, whose text must be recomputed every second
+	// from Block with CountdownDisplay; Block is nil when no block is active
+	Countdown bool
+	Block     *entities.Block
+}
+
+// CachedCostText represents cost text served from the cache before any fetch
+type CachedCostText struct {
+	Text      *entities.Text
+	Caption   string
+	Budget    *entities.BudgetStatus
+	Anomalies []entities.CostAnomaly
+	FetchedAt time.Time
+	Fresh     bool // Younger than the cache TTL, so no revalidation is needed
+}
+
 // CostDisplayUseCase handles the business logic for fetching and displaying cost data
 type CostDisplayUseCase struct {
-	costService interfaces.CostService
+	costService     interfaces.CostService
+	costCache       interfaces.CostCache
+	exchangeRates   interfaces.ExchangeRateProvider
+	budgets         interfaces.BudgetProvider
+	plans           interfaces.PlanProvider
+	pricingProvider interfaces.PricingProvider
+	anomalyOptions  entities.AnomalyOptions
+	modelGrouping   entities.ModelGrouping
+	budget          *entities.Budget // In the selected currency
+	plan            *entities.Plan   // Priced in USD
+	currency        entities.Currency
+	exchangeRate    float64 // Units of currency per US dollar
+	cacheEnabled    bool
+	cacheTTL        time.Duration
+	cacheSource     string   // Cost source the cache holds data of
+	cacheClaudeDirs []string // Claude directories the cache holds data of, sorted
+	metric          string
+	dateRange       entities.DateRange
+	now             func() time.Time
 }
 
 // NewCostDisplayUseCase creates a new CostDisplayUseCase
-func NewCostDisplayUseCase(costService interfaces.CostService) *CostDisplayUseCase {
+func NewCostDisplayUseCase(
+	costService interfaces.CostService,
+	costCache interfaces.CostCache,
+	exchangeRates interfaces.ExchangeRateProvider,
+	budgets interfaces.BudgetProvider,
+	plans interfaces.PlanProvider,
+	pricingProvider interfaces.PricingProvider,
+) *CostDisplayUseCase {
+	currency, _ := entities.LookupCurrency(entities.CurrencyUSD)
 	return &CostDisplayUseCase{
-		costService: costService,
+		costService:     costService,
+		costCache:       costCache,
+		exchangeRates:   exchangeRates,
+		budgets:         budgets,
+		plans:           plans,
+		pricingProvider: pricingProvider,
+		anomalyOptions:  entities.DefaultAnomalyOptions(),
+		modelGrouping:   entities.ModelGroupingModel,
+		currency:        currency,
+		exchangeRate:    1,
+		cacheEnabled:    true,
+		cacheTTL:        10 * time.Minute,
+		cacheSource:     interfaces.CostSourceAuto,
+		metric:          MetricTotal,
+		now:             time.Now,
+	}
+}
+
+// ConfigureCache enables or disables the cost data cache and sets how long cached data is fresh
+func (uc *CostDisplayUseCase) ConfigureCache(enabled bool, ttl time.Duration) {
+	uc.cacheEnabled = enabled
+	uc.cacheTTL = ttl
+}
+
+// SelectCacheSource keeps the cached data of every cost source and set of Claude directories
+// apart, so data from one is never served for another
+func (uc *CostDisplayUseCase) SelectCacheSource(options interfaces.CostSourceOptions) {
+	uc.cacheSource = options.Source
+	uc.cacheClaudeDirs = append([]string(nil), options.ClaudeDirs...)
+	sort.Strings(uc.cacheClaudeDirs)
+}
+
+// SelectMetric chooses which cost GetCostText displays
+func (uc *CostDisplayUseCase) SelectMetric(metric string) error {
+	if metric == MetricPlanValue && uc.plan == nil {
+		return fmt.Errorf("the %s metric needs a plan: pass --plan or create a plan file", MetricPlanValue)
+	}
+	for _, known := range metrics {
+		if metric == known {
+			uc.metric = metric
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown metric %q (expected %s or %s)",
+		metric, strings.Join(metrics[:len(metrics)-1], ", "), metrics[len(metrics)-1])
+}
+
+// SelectCurrency chooses the currency costs are displayed in. Rates come from the rate file at
+// ratesPath, or the default rate file when it is empty; plain USD needs no rate file.
+func (uc *CostDisplayUseCase) SelectCurrency(code, ratesPath string) error {
+	code = strings.ToUpper(code)
+	if code == entities.CurrencyUSD && ratesPath == "" {
+		uc.currency, _ = entities.LookupCurrency(entities.CurrencyUSD)
+		uc.exchangeRate = 1
+		return nil
+	}
+
+	rates, err := uc.exchangeRates.LoadRates(ratesPath)
+	if err != nil {
+		return err
+	}
+	currency, err := rates.Currency(code)
+	if err != nil {
+		return err
+	}
+	rate, err := rates.RateFromUSD(code)
+	if err != nil {
+		return err
+	}
+
+	uc.currency = currency
+	uc.exchangeRate = rate
+	return nil
+}
+
+// FormatCost converts a USD amount into the selected currency and formats it for display
+func (uc *CostDisplayUseCase) FormatCost(amount float64) string {
+	return uc.currency.Format(amount * uc.exchangeRate)
+}
+
+// SelectBudget loads the budget from the config file at path, or the default budget file when
+// it is empty; without a default budget file no budget is tracked
+func (uc *CostDisplayUseCase) SelectBudget(path string) error {
+	budget, err := uc.budgets.LoadBudget(path)
+	if err != nil {
+		return err
+	}
+	uc.budget = budget
+	return nil
+}
+
+// budgetStatus compares the spending of the budget's period with the budget, or returns nil
+// when no budget is set. The period is counted in full, whatever the date range.
+func (uc *CostDisplayUseCase) budgetStatus(allData *entities.CostResponse) *entities.BudgetStatus {
+	if uc.budget == nil {
+		return nil
+	}
+	// The budget is in the selected currency, spending in USD
+	budget := *uc.budget
+	budget.Amount /= uc.exchangeRate
+	return budget.Evaluate(allData.PeriodCost(budget.Period, uc.now()))
+}
+
+// SelectPlan loads the subscription plan from the config file at path, or the default plan file
+// when it is empty. A non-empty name (a plan name or a monthly price in USD) and a non-zero
+// billing day override the file; without either no plan is compared with.
+func (uc *CostDisplayUseCase) SelectPlan(path, name string, billingDay int) error {
+	plan, err := uc.plans.LoadPlan(path)
+	if err != nil {
+		return err
+	}
+	if name != "" {
+		parsed, err := entities.ParsePlan(name)
+		if err != nil {
+			return err
+		}
+		if plan != nil {
+			parsed.BillingDay = plan.BillingDay
+		}
+		plan = &parsed
+	}
+	if billingDay != 0 {
+		if plan == nil {
+			return fmt.Errorf("a billing day needs a plan: pass --plan or create a plan file")
+		}
+		plan.BillingDay = billingDay
+		if err := plan.Validate(); err != nil {
+			return err
+		}
+	}
+	uc.plan = plan
+	return nil
+}
+
+// GetPlanValues fetches the daily report and returns the value multiple of every billing month
+// that overlaps the date range, oldest first. Each month counts all of its usage, also outside
+// the range. A failed fetch may still return values from cached data together with a
+// *entities.StaleDataError.
+func (uc *CostDisplayUseCase) GetPlanValues(ctx context.Context) (*entities.Plan, []entities.PlanValue, error) {
+	if uc.plan == nil {
+		return nil, nil, fmt.Errorf("no plan to compare with: pass --plan or create a plan file")
+	}
+	allData, err := uc.fetchAllCostData(ctx)
+	if allData == nil {
+		return nil, nil, err
+	}
+	var values []entities.PlanValue
+	for _, value := range allData.PlanValueByBillingMonth(*uc.plan) {
+		// A month overlaps the range unless it ends before the start or starts after the end
+		last := value.End.AddDate(0, 0, -1).Format("2006-01-02")
+		first := value.Start.Format("2006-01-02")
+		if (uc.dateRange.Since == "" || last >= uc.dateRange.Since) && (uc.dateRange.Until == "" || first <= uc.dateRange.Until) {
+			values = append(values, value)
+		}
+	}
+	return uc.plan, values, err
+}
+
+// ConfigureAnomalies sets how spend anomalies are detected
+func (uc *CostDisplayUseCase) ConfigureAnomalies(options entities.AnomalyOptions) {
+	uc.anomalyOptions = options
+}
+
+// SelectModelGrouping chooses how models are grouped, e.g. in the model behind an anomaly
+func (uc *CostDisplayUseCase) SelectModelGrouping(grouping entities.ModelGrouping) {
+	uc.modelGrouping = grouping
+}
+
+// GetAnomalies fetches the daily report and returns its spend anomalies, oldest first.
+// A failed fetch may still return anomalies in cached data together with a *entities.StaleDataError.
+func (uc *CostDisplayUseCase) GetAnomalies(ctx context.Context) ([]entities.CostAnomaly, error) {
+	costData, err := uc.GetCostData(ctx)
+	if costData == nil {
+		return nil, err
+	}
+	return costData.GroupModels(uc.modelGrouping).DetectAnomalies(uc.anomalyOptions), err
+}
+
+// recentAnomalies returns the anomalies of the last week
+func (uc *CostDisplayUseCase) recentAnomalies(costData *entities.CostResponse) []entities.CostAnomaly {
+	since := uc.now().AddDate(0, 0, -recentAnomalyDays).Format("2006-01-02")
+	var recent []entities.CostAnomaly
+	for _, anomaly := range costData.GroupModels(uc.modelGrouping).DetectAnomalies(uc.anomalyOptions) {
+		if anomaly.Date > since {
+			recent = append(recent, anomaly)
+		}
+	}
+	return recent
+}
+
+// SelectDateRange limits the daily data to the given dates
+func (uc *CostDisplayUseCase) SelectDateRange(dateRange entities.DateRange) {
+	uc.dateRange = dateRange
+}
+
+// GetCostData fetches the raw cost data, restricted to the selected date range.
+// If the fetch fails but cached data exists, the cached data is returned together with
+// a *entities.StaleDataError.
+func (uc *CostDisplayUseCase) GetCostData(ctx context.Context) (*entities.CostResponse, error) {
+	allData, err := uc.fetchAllCostData(ctx)
+	if allData == nil {
+		return nil, err
+	}
+	return uc.filter(allData), err
+}
+
+// fetchAllCostData fetches the daily report of the fetch range, which also covers the budget
+// period and the plan's billing months outside the date range, and caches it. If the fetch
+// fails but cached data exists, the cached data is returned together with a
+// *entities.StaleDataError.
+func (uc *CostDisplayUseCase) fetchAllCostData(ctx context.Context) (*entities.CostResponse, error) {
+	costData, err := uc.costService.FetchCostData(ctx, uc.fetchRange())
+	if err != nil {
+		if cached := uc.loadCache(); cached != nil && ctx.Err() == nil {
+			return cached.Response, &entities.StaleDataError{FetchedAt: cached.FetchedAt, Err: err}
+		}
+		return nil, err
+	}
+	if uc.cacheEnabled {
+		// The cache is best effort, a read-only home directory must not break the display
+		_ = uc.costCache.Save(uc.cacheKey(), &entities.CachedCostResponse{Response: costData, FetchedAt: uc.now()})
+	}
+	return costData, nil
+}
+
+// GetCostDataBySource fetches the cost data of every source separately, restricted to the
+// selected date range. Per-source data is never cached.
+func (uc *CostDisplayUseCase) GetCostDataBySource(ctx context.Context) ([]entities.SourceCostResponse, error) {
+	sources, err := uc.costService.FetchCostDataBySource(ctx, uc.dateRange)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sources {
+		sources[i].Response = uc.filter(sources[i].Response)
+	}
+	return sources, nil
+}
+
+// GetCachedCostText returns the cost text computed from cached data, or nil if the selected
+// metric cannot be served from the cache or nothing is cached
+func (uc *CostDisplayUseCase) GetCachedCostText() *CachedCostText {
+	// Only the daily report is cached
+	if !uc.isDailyMetric() {
+		return nil
+	}
+	cached := uc.loadCache()
+	if cached == nil {
+		return nil
+	}
+	display := uc.dailyDisplay(cached.Response)
+	return &CachedCostText{
+		Text:      display.Text,
+		Caption:   display.Caption,
+		Budget:    display.Budget,
+		Anomalies: display.Anomalies,
+		FetchedAt: cached.FetchedAt,
+		Fresh:     cached.IsFresh(uc.cacheTTL, uc.now()),
 	}
 }
 
-// GetCostText fetches cost data and returns formatted text for display
-func (uc *CostDisplayUseCase) GetCostText() (*entities.Text, error) {
-	costData, err := uc.costService.FetchCostData()
+// loadCache returns the cached daily report, or nil if caching is disabled or nothing is cached
+func (uc *CostDisplayUseCase) loadCache() *entities.CachedCostResponse {
+	if !uc.cacheEnabled {
+		return nil
+	}
+	cached, err := uc.costCache.Load(uc.cacheKey())
 	if err != nil {
-		// Return error text if fetching fails
-		return entities.NewText("ERROR"), err
+		return nil
+	}
+	return cached
+}
+
+// cacheKey identifies the cached daily report of the selected source and fetch range, so a
+// report limited to some dates is never served for others
+func (uc *CostDisplayUseCase) cacheKey() string {
+	key := "daily-" + uc.cacheSource
+	if len(uc.cacheClaudeDirs) > 0 {
+		// Paths do not fit in a file name, a digest of them does
+		sum := sha256.Sum256([]byte(strings.Join(uc.cacheClaudeDirs, "\x00")))
+		key += "-" + hex.EncodeToString(sum[:8])
+	}
+	if fetchRange := uc.fetchRange(); !fetchRange.IsEmpty() {
+		key += "-" + fetchRange.Since + "-" + fetchRange.Until
+	}
+	return key
+}
+
+// fetchRange returns the dates the daily report is fetched for: the date range, widened to the
+// budget period and to the whole billing months the plan values count
+func (uc *CostDisplayUseCase) fetchRange() entities.DateRange {
+	fetchRange := uc.dateRange
+	if fetchRange.IsEmpty() {
+		return fetchRange
+	}
+	now := uc.now()
+	today := now.Format("2006-01-02")
+	if uc.budget != nil {
+		fetchRange = fetchRange.Including(uc.budget.Period.Start(now).Format("2006-01-02"), today)
+	}
+	if uc.plan != nil {
+		fetchRange = fetchRange.Including(uc.plan.BillingMonthStart(now).Format("2006-01-02"), today)
+		if since, err := time.Parse("2006-01-02", uc.dateRange.Since); err == nil {
+			fetchRange = fetchRange.Including(uc.plan.BillingMonthStart(since).Format("2006-01-02"), uc.dateRange.Since)
+		}
+		if until, err := time.Parse("2006-01-02", uc.dateRange.Until); err == nil {
+			fetchRange = fetchRange.Including(uc.dateRange.Until, uc.plan.BillingMonthEnd(until).Format("2006-01-02"))
+		}
 	}
+	return fetchRange
+}
 
-	// Format the total cost as text
-	costText := costData.Totals.FormatCost()
+// filter restricts cost data to the selected date range
+func (uc *CostDisplayUseCase) filter(costData *entities.CostResponse) *entities.CostResponse {
+	if uc.dateRange.IsEmpty() {
+		return costData
+	}
+	// The daily report is fetched for a wider range when budgets and plans look past it
+	return costData.FilterByDateRange(uc.dateRange)
+}
 
-	return entities.NewText(costText), nil
+// dailyMetricCost returns the cost of a metric served from the daily report of the fetch range. The
+// plan value covers the whole billing month, the other metrics the selected date range.
+func (uc *CostDisplayUseCase) dailyMetricCost(allData *entities.CostResponse) float64 {
+	if uc.metric == MetricPlanValue {
+		return allData.CurrentPlanValue(*uc.plan, uc.now()).Cost
+	}
+	costData := uc.filter(allData)
+	if uc.metric == MetricForecast {
+		return uc.forecast(costData).Expected
+	}
+	if uc.metric == MetricCacheSavings {
+		efficiency := costData.CacheEfficiencyTotal(uc.pricingProvider.GetModelPricing)
+		return efficiency.NetSaved()
+	}
+	if uc.metric == MetricToday {
+		today := uc.now().Format("2006-01-02")
+		for _, day := range costData.Daily {
+			if day.Date == today {
+				return day.TotalCost
+			}
+		}
+		return 0
+	}
+	return costData.Totals.TotalCost
+}
+
+// GetMetricCost fetches the report behind the selected metric and returns its cost in USD.
+// For metrics served from the daily report a failed fetch may still return a cached cost
+// together with a *entities.StaleDataError.
+func (uc *CostDisplayUseCase) GetMetricCost(ctx context.Context) (float64, error) {
+	switch uc.metric {
+	case MetricMonth:
+		monthlyData, err := uc.costService.FetchMonthlyData(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if month := monthlyData.FindMonth(uc.now().Format("2006-01")); month != nil {
+			return month.TotalCost, nil
+		}
+		return 0, nil
+	case MetricSession:
+		sessionData, err := uc.costService.FetchSessionData(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if session := sessionData.LatestSession(); session != nil {
+			return session.TotalCost, nil
+		}
+		return 0, nil
+	case MetricBlock, MetricCountdown:
+		blocksData, err := uc.costService.FetchBlocksData(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if block := blocksData.ActiveBlock(); block != nil {
+			return block.CostUSD, nil
+		}
+		return 0, nil
+	default:
+		// A stale cache hit returns data and an error, pass both on
+		allData, err := uc.fetchAllCostData(ctx)
+		if allData == nil {
+			return 0, err
+		}
+		return uc.dailyMetricCost(allData), err
+	}
+}
+
+// GetCostText fetches cost data and returns formatted text for display.
+// The text is the cached cost when the error is a *entities.StaleDataError, and nil on other errors.
+func (uc *CostDisplayUseCase) GetCostText(ctx context.Context) (*entities.Text, error) {
+	cost, err := uc.GetMetricCost(ctx)
+	var staleErr *entities.StaleDataError
+	if err != nil && !errors.As(err, &staleErr) {
+		return nil, err
+	}
+
+	// Format the cost as text
+	costText := uc.FormatCost(cost)
+
+	return entities.NewText(costText), err
+}
+
+// GetCostDisplay fetches cost data and returns the formatted text for display together with its
+// caption: the confidence band of the forecast metric, or the month-end forecast of the month
+// metric. Every metric but the countdown also gets the budget status and last week's spend
+// anomalies from the daily report. Like GetCostText, the text is the cached cost when the error is a *entities.StaleDataError,
+// and nil on other errors.
+func (uc *CostDisplayUseCase) GetCostDisplay(ctx context.Context) (*CostDisplay, error) {
+	if uc.metric == MetricCountdown {
+		blocksData, err := uc.costService.FetchBlocksData(ctx)
+		if err != nil {
+			return &CostDisplay{}, err
+		}
+		block := blocksData.ActiveBlock()
+		display := uc.CountdownDisplay(block, uc.now())
+		display.Countdown = true
+		display.Block = block
+		return display, nil
+	}
+
+	if uc.isDailyMetric() {
+		allData, err := uc.fetchAllCostData(ctx)
+		if allData == nil {
+			return &CostDisplay{}, err
+		}
+		return uc.dailyDisplay(allData), err
+	}
+
+	text, err := uc.GetCostText(ctx)
+	display := &CostDisplay{Text: text}
+	if err != nil {
+		return display, err
+	}
+
+	// The forecast, budget and anomalies are extra information, the cost is shown without them if they fail.
+	// Anomalies come from the daily report whatever the metric, like on the daily views.
+	allData, dailyErr := uc.fetchAllCostData(ctx)
+	if allData == nil || dailyErr != nil {
+		return display, nil
+	}
+	costData := uc.filter(allData)
+	if uc.metric == MetricMonth {
+		forecast := uc.forecast(costData)
+		display.Caption = fmt.Sprintf("Month-end forecast %s (%s - %s)",
+			uc.FormatCost(forecast.Expected), uc.FormatCost(forecast.Low), uc.FormatCost(forecast.High))
+	}
+	display.Budget = uc.budgetStatus(allData)
+	display.Anomalies = uc.recentAnomalies(costData)
+	return display, nil
+}
+
+// CountdownDisplay returns the time left in the block as text, with the block's projected cost
+// at the current burn rate as caption
+func (uc *CostDisplayUseCase) CountdownDisplay(block *entities.Block, now time.Time) *CostDisplay {
+	if block == nil {
+		return &CostDisplay{Text: entities.NewText("0:00:00"), Caption: "No active block"}
+	}
+
+	remaining := block.Remaining(now).Truncate(time.Second)
+	hours := int(remaining.Hours())
+	minutes := int(remaining.Minutes()) % 60
+	seconds := int(remaining.Seconds()) % 60
+	return &CostDisplay{
+		Text: entities.NewText(fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)),
+		Caption: fmt.Sprintf("Block resets at %s · projected %s · %s so far · %.0f tokens/min",
+			block.EndTime.In(now.Location()).Format("15:04"), uc.FormatCost(block.ProjectedCost(now)),
+			uc.FormatCost(block.CostUSD), block.TokensPerMinute(now)),
+	}
+}
+
+// isDailyMetric reports whether the selected metric is computed from the daily report alone
+func (uc *CostDisplayUseCase) isDailyMetric() bool {
+	switch uc.metric {
+	case MetricTotal, MetricToday, MetricForecast, MetricCacheSavings, MetricPlanValue:
+		return true
+	default:
+		return false
+	}
+}
+
+// forecast projects this month's cost from the daily report
+func (uc *CostDisplayUseCase) forecast(costData *entities.CostResponse) *entities.CostForecast {
+	return costData.ForecastMonth(uc.now(), entities.DefaultForecastWindow)
+}
+
+// dailyDisplay returns the display of a metric served from the daily report of the fetch range
+func (uc *CostDisplayUseCase) dailyDisplay(allData *entities.CostResponse) *CostDisplay {
+	costData := uc.filter(allData)
+	display := &CostDisplay{Budget: uc.budgetStatus(allData), Anomalies: uc.recentAnomalies(costData)}
+	switch uc.metric {
+	case MetricForecast:
+		forecast := uc.forecast(costData)
+		display.Text = entities.NewText(uc.FormatCost(forecast.Expected))
+		display.Caption = fmt.Sprintf("95%% band %s - %s · trailing average %s · trend %s · %s so far",
+			uc.FormatCost(forecast.Low), uc.FormatCost(forecast.High),
+			uc.FormatCost(forecast.TrailingAverage), uc.FormatCost(forecast.Regression), uc.FormatCost(forecast.ToDate))
+	case MetricCacheSavings:
+		efficiency := costData.CacheEfficiencyTotal(uc.pricingProvider.GetModelPricing)
+		display.Text = entities.NewText(uc.FormatCost(efficiency.NetSaved()))
+		display.Caption = fmt.Sprintf("Saved by prompt caching · %.1f%% cache hit ratio · %s saved on reads · %s paid for writes",
+			efficiency.HitRatio()*100, uc.FormatCost(efficiency.Saved), uc.FormatCost(efficiency.WritePremium))
+		if efficiency.Unpriced > 0 {
+			display.Caption += fmt.Sprintf(" · %d unpriced", efficiency.Unpriced)
+		}
+	case MetricPlanValue:
+		value := allData.CurrentPlanValue(*uc.plan, uc.now())
+		display.Text = entities.NewText(fmt.Sprintf("%.1fx", value.Multiple))
+		display.Caption = fmt.Sprintf("%s · %s API-equivalent since %s vs %s plan price",
+			uc.plan.DisplayName(), uc.FormatCost(value.Cost), value.Start.Format("Jan 2"), uc.FormatCost(value.Price))
+	default:
+		display.Text = entities.NewText(uc.FormatCost(uc.dailyMetricCost(allData)))
+	}
+	return display
 }
@@ -0,0 +1,107 @@
+package pricing
+
+import (
+	"ccusage-rainbow/internal/domain/entities"
+	"ccusage-rainbow/internal/domain/interfaces"
+)
+
+// CostCalculatorUseCase handles the business logic for computing costs from token counts
+type CostCalculatorUseCase struct {
+	pricingProvider interfaces.PricingProvider
+	modelGrouping   entities.ModelGrouping
+}
+
+// NewCostCalculatorUseCase creates a new CostCalculatorUseCase
+func NewCostCalculatorUseCase(pricingProvider interfaces.PricingProvider) *CostCalculatorUseCase {
+	return &CostCalculatorUseCase{
+		pricingProvider: pricingProvider,
+		modelGrouping:   entities.ModelGroupingModel,
+	}
+}
+
+// SelectModelGrouping chooses how models are grouped in per-model results
+func (uc *CostCalculatorUseCase) SelectModelGrouping(grouping entities.ModelGrouping) {
+	uc.modelGrouping = grouping
+}
+
+// LoadPricingOverrides merges prices from a LiteLLM-style JSON file over the built-in table
+func (uc *CostCalculatorUseCase) LoadPricingOverrides(path string) error {
+	return uc.pricingProvider.LoadOverrides(path)
+}
+
+// Recalculate returns a copy of the cost data with ModelBreakdown.Cost, DailyUsage.TotalCost
+// and Totals.TotalCost recomputed from token counts. Models without known pricing keep their reported cost.
+func (uc *CostCalculatorUseCase) Recalculate(costData *entities.CostResponse) *entities.CostResponse {
+	result := &entities.CostResponse{
+		Daily:  make([]entities.DailyUsage, len(costData.Daily)),
+		Totals: costData.Totals,
+	}
+	result.Totals.TotalCost = 0
+
+	for i, day := range costData.Daily {
+		day.ModelBreakdowns = append([]entities.ModelBreakdown(nil), day.ModelBreakdowns...)
+		// Days without a breakdown cannot be repriced
+		if len(day.ModelBreakdowns) > 0 {
+			day.TotalCost = 0
+			for j := range day.ModelBreakdowns {
+				breakdown := &day.ModelBreakdowns[j]
+				if pricing, err := uc.pricingProvider.GetModelPricing(breakdown.ModelName); err == nil {
+					breakdown.Cost = pricing.CalculateBreakdownCost(breakdown)
+				}
+				day.TotalCost += breakdown.Cost
+			}
+		}
+		result.Daily[i] = day
+		result.Totals.TotalCost += day.TotalCost
+	}
+
+	return result
+}
+
+// CacheEfficiencyByDay returns how well prompt caching worked on every day
+func (uc *CostCalculatorUseCase) CacheEfficiencyByDay(costData *entities.CostResponse) []entities.CacheEfficiency {
+	return costData.CacheEfficiencyByDay(uc.pricingProvider.GetModelPricing)
+}
+
+// CacheEfficiencyByModel returns how well prompt caching worked for every model group, largest net savings first
+func (uc *CostCalculatorUseCase) CacheEfficiencyByModel(costData *entities.CostResponse) []entities.CacheEfficiency {
+	return costData.CacheEfficiencyByModel(uc.pricingProvider.GetModelPricing, uc.modelGrouping)
+}
+
+// CacheEfficiencyTotal returns how well prompt caching worked over all usage
+func (uc *CostCalculatorUseCase) CacheEfficiencyTotal(costData *entities.CostResponse) entities.CacheEfficiency {
+	return costData.CacheEfficiencyTotal(uc.pricingProvider.GetModelPricing)
+}
+
+// Simulate reprices the cost data as if the tokens of models had gone to other models by the rules
+func (uc *CostCalculatorUseCase) Simulate(costData *entities.CostResponse, rules []entities.SubstitutionRule) (*entities.CostSimulation, error) {
+	return costData.Simulate(rules, uc.pricingProvider.GetModelPricing)
+}
+
+// CrossCheck compares the reported cost of every model group and day with the cost recomputed from
+// its token counts. Models are priced by their raw IDs; in a group with some priced models the
+// unpriced ones count at their reported cost.
+func (uc *CostCalculatorUseCase) CrossCheck(costData *entities.CostResponse) []entities.CostDiscrepancy {
+	var discrepancies []entities.CostDiscrepancy
+	for _, day := range costData.Daily {
+		index := make(map[string]int)
+		for _, breakdown := range day.ModelBreakdowns {
+			label := uc.modelGrouping.Label(breakdown.ModelName)
+			i, ok := index[label]
+			if !ok {
+				i = len(discrepancies)
+				index[label] = i
+				discrepancies = append(discrepancies, entities.CostDiscrepancy{Date: day.Date, ModelName: label})
+			}
+			discrepancy := &discrepancies[i]
+			discrepancy.ReportedCost += breakdown.Cost
+			if pricing, err := uc.pricingProvider.GetModelPricing(breakdown.ModelName); err == nil {
+				discrepancy.ComputedCost += pricing.CalculateBreakdownCost(&breakdown)
+				discrepancy.Priced = true
+			} else {
+				discrepancy.ComputedCost += breakdown.Cost
+			}
+		}
+	}
+	return discrepancies
+}
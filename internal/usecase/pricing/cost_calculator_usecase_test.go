@@ -0,0 +1,65 @@
+package pricing
+
+import (
+	"ccusage-rainbow/internal/domain/entities"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// stubPricing prices Sonnet at $1 an input and $2 an output token and knows no other model
+type stubPricing struct{}
+
+func (stubPricing) GetModelPricing(modelName string) (*entities.ModelPricing, error) {
+	if entities.LookupModel(modelName).Family != entities.ModelFamilySonnet {
+		return nil, fmt.Errorf("no pricing for model %q", modelName)
+	}
+	return &entities.ModelPricing{InputCostPerToken: 1, OutputCostPerToken: 2}, nil
+}
+
+func (stubPricing) LoadOverrides(path string) error {
+	return nil
+}
+
+func TestRecalculate(t *testing.T) {
+	costData := &entities.CostResponse{
+		Daily: []entities.DailyUsage{
+			{Date: "2025-03-01", TotalCost: 100, ModelBreakdowns: []entities.ModelBreakdown{
+				{ModelName: "claude-sonnet-4-20250514", InputTokens: 3, OutputTokens: 1, Cost: 90},
+				{ModelName: "gpt-4o", InputTokens: 3, OutputTokens: 1, Cost: 10},
+			}},
+			// Without breakdowns a day keeps its cost
+			{Date: "2025-03-02", TotalCost: 7},
+		},
+		Totals: entities.Totals{TotalCost: 107, TotalTokens: 8},
+	}
+
+	recalculated := NewCostCalculatorUseCase(stubPricing{}).Recalculate(costData)
+
+	want := []struct {
+		total      float64
+		breakdowns []float64
+	}{
+		{5 + 10, []float64{5, 10}},
+		{7, nil},
+	}
+	for i, w := range want {
+		day := recalculated.Daily[i]
+		if math.Abs(day.TotalCost-w.total) > 1e-9 {
+			t.Errorf("%s: got cost %v, want %v", day.Date, day.TotalCost, w.total)
+		}
+		for j, cost := range w.breakdowns {
+			if got := day.ModelBreakdowns[j].Cost; math.Abs(got-cost) > 1e-9 {
+				t.Errorf("%s %s: got cost %v, want %v", day.Date, day.ModelBreakdowns[j].ModelName, got, cost)
+			}
+		}
+	}
+	if math.Abs(recalculated.Totals.TotalCost-22) > 1e-9 || recalculated.Totals.TotalTokens != 8 {
+		t.Errorf("got totals %+v, want cost 22 and 8 tokens", recalculated.Totals)
+	}
+
+	// The input is left as it was
+	if costData.Daily[0].TotalCost != 100 || costData.Daily[0].ModelBreakdowns[0].Cost != 90 || costData.Totals.TotalCost != 107 {
+		t.Errorf("input changed: %+v", costData)
+	}
+}
@@ -3,14 +3,35 @@ package rainbow
 import (
 	"ccusage-rainbow/internal/domain/entities"
 	"ccusage-rainbow/internal/domain/interfaces"
+	"math"
 	"time"
 )
 
+// defaultAnimationInterval is the animation speed while no budget threshold is crossed
+const defaultAnimationInterval = 100 * time.Millisecond
+
+// fontSizePadding is the number of rows kept free around the text when choosing the font size
+const fontSizePadding = 5
+
+// fontSizeKey identifies what the optimal font size depends on
+type fontSizeKey struct {
+	content        string
+	terminalWidth  int
+	terminalHeight int
+	renderMode     interfaces.RenderMode
+	font           string
+}
+
 // RainbowTextUseCase handles the business logic for displaying animated rainbow text
 type RainbowTextUseCase struct {
 	asciiRenderer interfaces.ASCIIRenderer
 	colorAnimator interfaces.ColorAnimator
 	animation     *entities.RainbowAnimation
+	renderMode    interfaces.RenderMode
+	font          string
+	// The last optimal font size and what it was chosen for; every frame asks again
+	fontSizeKey *fontSizeKey
+	fontSize    interfaces.FontSize
 }
 
 // NewRainbowTextUseCase creates a new RainbowTextUseCase
@@ -21,7 +42,8 @@ func NewRainbowTextUseCase(
 	return &RainbowTextUseCase{
 		asciiRenderer: asciiRenderer,
 		colorAnimator: colorAnimator,
-		animation:     entities.NewRainbowAnimation(100 * time.Millisecond),
+		animation:     entities.NewRainbowAnimation(defaultAnimationInterval),
+		renderMode:    interfaces.RenderModeBlock,
 	}
 }
 
@@ -44,6 +66,11 @@ func (uc *RainbowTextUseCase) RenderAnimatedTextWithSize(text *entities.Text, si
 	return coloredASCII, nil
 }
 
+// RenderPlainText renders text without colors (uses medium size)
+func (uc *RainbowTextUseCase) RenderPlainText(text *entities.Text) (string, error) {
+	return uc.asciiRenderer.RenderPlainWithSize(text, interfaces.FontSizeMedium)
+}
+
 // GetDisplayWidth calculates the display width of the rendered text (uses medium size)
 func (uc *RainbowTextUseCase) GetDisplayWidth(text *entities.Text) (int, error) {
 	return uc.GetDisplayWidthWithSize(text, interfaces.FontSizeMedium)
@@ -54,22 +81,96 @@ func (uc *RainbowTextUseCase) GetDisplayWidthWithSize(text *entities.Text, size
 	return uc.asciiRenderer.GetDisplayWidthWithSize(text, size)
 }
 
-// SelectOptimalFontSize chooses the best font size based on terminal dimensions
+// SelectFont chooses the font the text is drawn in, by name or font file path
+func (uc *RainbowTextUseCase) SelectFont(name string) error {
+	if err := uc.asciiRenderer.SelectFont(name); err != nil {
+		return err
+	}
+	uc.font = name
+	return nil
+}
+
+// SelectRenderMode chooses how finely the built-in and bitmap glyphs are drawn: block, half, quadrant or braille
+func (uc *RainbowTextUseCase) SelectRenderMode(value string) error {
+	mode, err := interfaces.ParseRenderMode(value)
+	if err != nil {
+		return err
+	}
+	uc.renderMode = mode
+	uc.asciiRenderer.SelectRenderMode(mode)
+	return nil
+}
+
+// ListFonts returns the names of the fonts that can be selected
+func (uc *RainbowTextUseCase) ListFonts() ([]string, error) {
+	return uc.asciiRenderer.ListFonts()
+}
+
+// SelectOptimalFontSize chooses the largest font size at which the text fits the terminal. The
+// choice is kept until the text, the terminal size, the render mode or the font changes.
 func (uc *RainbowTextUseCase) SelectOptimalFontSize(text *entities.Text, terminalWidth, terminalHeight int) (interfaces.FontSize, error) {
-	// Try large size first
-	largeWidth, err := uc.GetDisplayWidthWithSize(text, interfaces.FontSizeLarge)
-	if err == nil && largeWidth <= terminalWidth && terminalHeight >= 15 { // Need at least 15 rows for large (10 + padding)
-		return interfaces.FontSizeLarge, nil
+	key := fontSizeKey{text.Content, terminalWidth, terminalHeight, uc.renderMode, uc.font}
+	if uc.fontSizeKey != nil && *uc.fontSizeKey == key {
+		return uc.fontSize, nil
+	}
+
+	rows, err := uc.maxFittingRows(text, terminalWidth)
+	if err != nil {
+		return interfaces.FontSizeSmall, err
 	}
+	rows = min(rows, terminalHeight-fontSizePadding)
 
-	// Try medium size
-	mediumWidth, err := uc.GetDisplayWidthWithSize(text, interfaces.FontSizeMedium)
-	if err == nil && mediumWidth <= terminalWidth && terminalHeight >= 12 { // Need at least 12 rows for medium (7 + padding)
-		return interfaces.FontSizeMedium, nil
+	// Step down a row at a time from there until the text fits; finer render modes go below the
+	// small size. Without a fitting size, fall back to the smallest.
+	smallest := uc.renderMode.MinFontSize()
+	size := smallest
+	for ; rows > smallest.Rows(); rows-- {
+		width, err := uc.GetDisplayWidthWithSize(text, interfaces.FontSizeForRows(rows))
+		if err == nil && width <= terminalWidth {
+			size = interfaces.FontSizeForRows(rows)
+			break
+		}
 	}
 
-	// Fall back to small size
-	return interfaces.FontSizeSmall, nil
+	uc.fontSizeKey, uc.fontSize = &key, size
+	return size, nil
+}
+
+// maxFittingRows returns a number of rows above which the text cannot fit the terminal width.
+// Scaled glyphs are as wide per row as the hand-drawn size they are scaled from, so the
+// narrowest of the sizes the render mode draws from bounds the rows, give or take rounding.
+func (uc *RainbowTextUseCase) maxFittingRows(text *entities.Text, terminalWidth int) (int, error) {
+	_, cellHeight := uc.renderMode.CellPixels()
+	bound := 0
+	for _, base := range []interfaces.FontSize{interfaces.FontSizeSmall, interfaces.FontSizeMedium, interfaces.FontSizeLarge} {
+		// The fewest rows drawn from this size, unless the mode skips it for a larger one
+		rows := (base.Rows() + cellHeight - 1) / cellHeight
+		width, err := uc.GetDisplayWidthWithSize(text, interfaces.FontSizeForRows(rows))
+		if err != nil {
+			return 0, err
+		}
+		if width == 0 {
+			return math.MaxInt, nil
+		}
+		bound = max(bound, terminalWidth*rows/width+1)
+	}
+	return bound, nil
+}
+
+// SetBudgetLevel makes the animation escalate with the budget level: warmer colors and a faster
+// cycle as spending approaches the limit
+func (uc *RainbowTextUseCase) SetBudgetLevel(level entities.BudgetLevel) {
+	switch level {
+	case entities.BudgetLevelCritical:
+		uc.animation.SetPalette(entities.PaletteCritical)
+		uc.animation.SetInterval(35 * time.Millisecond)
+	case entities.BudgetLevelWarning:
+		uc.animation.SetPalette(entities.PaletteWarning)
+		uc.animation.SetInterval(60 * time.Millisecond)
+	default:
+		uc.animation.SetPalette(entities.PaletteRainbow)
+		uc.animation.SetInterval(defaultAnimationInterval)
+	}
 }
 
 // AdvanceAnimation advances the animation to the next frame